@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/AustralianCyberSecurityCentre/azul-plugin-goinfo.git/goinfo"
+)
+
+// Name of the subcommand that analyses local files instead of running as a plugin.
+const analyzeCommand = "analyze"
+
+// Print a feature as tab separated name, value, label, offset and size, leaving out the offset and size when there isn't one.
+func printFeature(out io.Writer, feature goinfo.Feature) {
+	line := feature.Name + "\t" + feature.Value + "\t" + feature.Label
+	if feature.Offset != 0 || feature.Size != 0 {
+		line += fmt.Sprintf("\t%#x\t%d", feature.Offset, feature.Size)
+	}
+	fmt.Fprintln(out, line)
+}
+
+// Run the plugin's analysis on local files and print the features, or the JSON reports, to out.
+// Returns the exit code, which is 1 if any file failed and 2 for bad arguments.
+func runAnalyzeCommand(settings *goinfo.Settings, args []string, out io.Writer, errOut io.Writer) int {
+	flags := flag.NewFlagSet(analyzeCommand, flag.ContinueOnError)
+	flags.SetOutput(errOut)
+	printReport := flags.Bool("report", false, "print the JSON analysis report of each file instead of its features")
+	flags.Usage = func() {
+		fmt.Fprintf(errOut, "usage: %s %s [-report] <file...>\n", os.Args[0], analyzeCommand)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	analyzer := goinfo.NewAnalyzer(settings)
+	exitCode := 0
+	for _, path := range flags.Args() {
+		if _, err := os.Stat(path); err != nil {
+			fmt.Fprintf(errOut, "%s: %v\n", path, err)
+			exitCode = 1
+			continue
+		}
+		report, err := analyzer.Analyze(ctx, path)
+		if err != nil {
+			fmt.Fprintf(errOut, "%s: analysis failed: %v\n", path, err)
+			exitCode = 1
+			continue
+		}
+		if report.OptOut != "" {
+			fmt.Fprintf(errOut, "%s: opted out: %s\n", path, report.OptOut)
+			continue
+		}
+		if *printReport {
+			for _, binary := range report.Binaries {
+				binaryJSON, err := json.Marshal(binary)
+				if err != nil {
+					fmt.Fprintf(errOut, "%s: report failed: %v\n", path, err)
+					exitCode = 1
+					continue
+				}
+				fmt.Fprintln(out, string(binaryJSON))
+			}
+			continue
+		}
+		// Features of each file follow a header line when more than one file is analysed.
+		if flags.NArg() > 1 {
+			fmt.Fprintf(out, "# %s\n", path)
+		}
+		for _, feature := range report.Features {
+			printFeature(out, feature)
+		}
+	}
+	return exitCode
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AustralianCyberSecurityCentre/azul-plugin-goinfo.git/goinfo"
+)
+
+func TestAnalyzeCommand(t *testing.T) {
+	// The test binary is itself a Go binary to analyse.
+	binaryPath := os.Args[0]
+
+	var out, errOut bytes.Buffer
+	if code := runAnalyzeCommand(nil, []string{binaryPath}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0 got %d %s", code, errOut.String())
+	}
+	// Gore lists the root package under its escaped path, "azul-plugin-goinfo%2egit", as a library, so look for a function in goinfo.
+	if !strings.Contains(out.String(), "go_compiler_version\tgo1.") || !strings.Contains(out.String(), "go_package_function\tdetectBuildMode\t") {
+		t.Errorf("expected features in the output got %s", out.String())
+	}
+
+	out.Reset()
+	if code := runAnalyzeCommand(nil, []string{"-report", binaryPath}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0 got %d %s", code, errOut.String())
+	}
+	report := goinfo.BinaryReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected a single JSON report got %s", out.String())
+	}
+	if report.ReportVersion != 1 || len(report.Packages) == 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestAnalyzeCommandArguments(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := runAnalyzeCommand(nil, []string{}, &out, &errOut); code != 2 {
+		t.Errorf("expected exit code 2 without files got %d", code)
+	}
+	if code := runAnalyzeCommand(nil, []string{"-unknown"}, &out, &errOut); code != 2 {
+		t.Errorf("expected exit code 2 for an unknown flag got %d", code)
+	}
+	errOut.Reset()
+	if code := runAnalyzeCommand(nil, []string{"/missing/sample.exe"}, &out, &errOut); code != 1 {
+		t.Errorf("expected exit code 1 for a missing file got %d", code)
+	}
+	if !strings.HasPrefix(errOut.String(), "/missing/sample.exe: ") {
+		t.Errorf("expected the missing file in the error got %s", errOut.String())
+	}
+}
@@ -0,0 +1,389 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Labels used to show where a build mode or link mode value was derived from.
+const (
+	modeSourceBuildSetting = "build_setting"
+	modeSourceHeuristic    = "heuristic"
+)
+
+// Index of the export table in the PE optional header data directories.
+const peExportDirectoryIndex = 0
+
+// Magic bytes at the start of a static archive, produced by -buildmode=c-archive.
+var arArchiveMagic = []byte("!<arch>\n")
+
+// Matches the link mode passed through -ldflags, e.g '-linkmode=external' or '-linkmode external'.
+var ldflagsLinkModeRegex = regexp.MustCompile(`-linkmode[= ]+["']?(internal|external)`)
+
+// Symbol prefixes that are generated by the Go toolchain rather than a //export directive.
+var goInternalExportPrefixes = []string{
+	"_cgo",
+	"_rt0_",
+	"x_cgo",
+	"crosscall",
+	"go:",
+	"go.",
+	"__",
+	"_init",
+	"_fini",
+	"_start",
+	"_end",
+	"_edata",
+	"_IO_",
+}
+
+// How a Go binary was built and linked.
+type goBuildModeInfo struct {
+	buildMode       string
+	buildModeSource string
+	linkMode        string
+	linkModeSource  string
+	// Symbols exported with //export in a c-shared build.
+	cgoExports []string
+}
+
+// Detect the build mode and link mode of a Go binary.
+// Build settings embedded by the compiler are used when present, otherwise the file headers are inspected.
+func detectBuildMode(filePath string, buildSettings map[string]string) *goBuildModeInfo {
+	info := &goBuildModeInfo{}
+	if buildMode, ok := buildSettings["-buildmode"]; ok && buildMode != "" {
+		info.buildMode = buildMode
+		info.buildModeSource = modeSourceBuildSetting
+	}
+	if linkMode, ok := buildSettings["-linkmode"]; ok && linkMode != "" {
+		info.linkMode = linkMode
+		info.linkModeSource = modeSourceBuildSetting
+	} else if match := ldflagsLinkModeRegex.FindStringSubmatch(buildSettings["-ldflags"]); match != nil {
+		info.linkMode = match[1]
+		info.linkModeSource = modeSourceBuildSetting
+	}
+
+	rawFile, err := os.Open(filePath)
+	if err != nil {
+		return info
+	}
+	defer rawFile.Close()
+	magic := make([]byte, len(arArchiveMagic))
+	_, err = rawFile.ReadAt(magic, 0)
+	if err != nil {
+		return info
+	}
+
+	var heuristicBuildMode, heuristicLinkMode string
+	switch {
+	case bytes.Equal(magic, arArchiveMagic):
+		// A c-archive is always linked by the C toolchain that consumes it.
+		heuristicBuildMode, heuristicLinkMode = "c-archive", "external"
+	case bytes.HasPrefix(magic, []byte(elf.ELFMAG)):
+		heuristicBuildMode, heuristicLinkMode, info.cgoExports = elfBuildMode(rawFile)
+	case bytes.HasPrefix(magic, []byte("MZ")):
+		heuristicBuildMode, heuristicLinkMode, info.cgoExports = peBuildMode(rawFile)
+	default:
+		heuristicBuildMode, heuristicLinkMode, info.cgoExports = machoBuildMode(rawFile)
+	}
+
+	if info.buildMode == "" && heuristicBuildMode != "" {
+		info.buildMode = heuristicBuildMode
+		info.buildModeSource = modeSourceHeuristic
+	}
+	if info.linkMode == "" && heuristicLinkMode != "" {
+		info.linkMode = heuristicLinkMode
+		info.linkModeSource = modeSourceHeuristic
+	}
+	// Exports are only meaningful for shared libraries, an executable can export symbols for other reasons.
+	if info.buildMode != "c-shared" {
+		info.cgoExports = nil
+	}
+	return info
+}
+
+// Returns true if the symbol looks like it was exported with a //export directive.
+func isCgoExportName(name string) bool {
+	// Go symbols always contain the package path separated with a dot.
+	if name == "" || strings.Contains(name, ".") {
+		return false
+	}
+	for _, prefix := range goInternalExportPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort and remove duplicates and toolchain generated symbols from a list of exports.
+func filterCgoExports(names []string) []string {
+	exports := []string{}
+	seen := map[string]struct{}{}
+	for _, name := range names {
+		if _, ok := seen[name]; ok || !isCgoExportName(name) {
+			continue
+		}
+		seen[name] = struct{}{}
+		exports = append(exports, name)
+	}
+	sort.Strings(exports)
+	return exports
+}
+
+// Returns true if any symbol in the list starts with the provided prefix and ends with the provided suffix.
+func hasSymbol(names []string, prefix string, suffix string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Go plugins carry a table of exported symbols the plugin package looks up when loading them.
+func isGoPlugin(names []string) bool {
+	return hasSymbol(names, "go:plugin.tabs", "") || hasSymbol(names, "go.plugin.tabs", "")
+}
+
+func elfBuildMode(rawFile *os.File) (string, string, []string) {
+	elfFile, err := elf.NewFile(rawFile)
+	if err != nil {
+		return "", "", nil
+	}
+	defer elfFile.Close()
+
+	symbolNames := []string{}
+	// Stripped binaries have no symbol table, so fall back to the dynamic symbols only.
+	symbols, _ := elfFile.Symbols()
+	for _, symbol := range symbols {
+		symbolNames = append(symbolNames, symbol.Name)
+	}
+	exportNames := []string{}
+	dynamicSymbols, _ := elfFile.DynamicSymbols()
+	for _, symbol := range dynamicSymbols {
+		symbolNames = append(symbolNames, symbol.Name)
+		if symbol.Section != elf.SHN_UNDEF && elf.ST_BIND(symbol.Info) == elf.STB_GLOBAL && elf.ST_TYPE(symbol.Info) == elf.STT_FUNC {
+			exportNames = append(exportNames, symbol.Name)
+		}
+	}
+
+	hasInterpreter := false
+	for _, prog := range elfFile.Progs {
+		if prog.Type == elf.PT_INTERP {
+			hasInterpreter = true
+		}
+	}
+
+	buildMode := ""
+	switch elfFile.Type {
+	case elf.ET_EXEC:
+		buildMode = "exe"
+	case elf.ET_DYN:
+		switch {
+		case isGoPlugin(symbolNames):
+			buildMode = "plugin"
+		// Libraries use a different runtime entry point so they can be initialised by the C loader.
+		case hasSymbol(symbolNames, "_rt0_", "_lib"):
+			buildMode = "c-shared"
+		// PIE executables ask for the dynamic loader and libraries don't, which still holds when stripping
+		// has removed the _rt0_ symbol from the symbol table.
+		case hasInterpreter:
+			buildMode = "pie"
+		default:
+			buildMode = "c-shared"
+		}
+	case elf.ET_REL:
+		buildMode = "c-archive"
+	}
+
+	// The Go linker doesn't write a .comment section or pull in the C runtime start files.
+	linkMode := "internal"
+	if elfFile.Section(".comment") != nil || elfFile.Section(".eh_frame_hdr") != nil || hasSymbol(symbolNames, "_IO_stdin_used", "") {
+		linkMode = "external"
+	}
+	return buildMode, linkMode, filterCgoExports(exportNames)
+}
+
+func peBuildMode(rawFile *os.File) (string, string, []string) {
+	peFile, err := pe.NewFile(rawFile)
+	if err != nil {
+		return "", "", nil
+	}
+	defer peFile.Close()
+
+	var majorLinkerVersion, minorLinkerVersion uint8
+	var dllCharacteristics uint16
+	switch oh := peFile.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		majorLinkerVersion, minorLinkerVersion, dllCharacteristics = oh.MajorLinkerVersion, oh.MinorLinkerVersion, oh.DllCharacteristics
+	case *pe.OptionalHeader64:
+		majorLinkerVersion, minorLinkerVersion, dllCharacteristics = oh.MajorLinkerVersion, oh.MinorLinkerVersion, oh.DllCharacteristics
+	}
+
+	exportNames := peExportNames(peFile)
+	buildMode := "exe"
+	switch {
+	case peFile.Characteristics&pe.IMAGE_FILE_DLL != 0:
+		buildMode = "c-shared"
+	case dllCharacteristics&pe.IMAGE_DLLCHARACTERISTICS_DYNAMIC_BASE != 0 && peFile.Section(".reloc") != nil:
+		buildMode = "pie"
+	}
+
+	// The Go linker always writes a linker version of 3.0, anything else came from an external linker.
+	linkMode := "external"
+	if majorLinkerVersion == 3 && minorLinkerVersion == 0 {
+		linkMode = "internal"
+	}
+	return buildMode, linkMode, filterCgoExports(exportNames)
+}
+
+// Read the names from the export directory of a PE file.
+func peExportNames(peFile *pe.File) []string {
+	var exportDirectory pe.DataDirectory
+	switch oh := peFile.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if oh.NumberOfRvaAndSizes > peExportDirectoryIndex {
+			exportDirectory = oh.DataDirectory[peExportDirectoryIndex]
+		}
+	case *pe.OptionalHeader64:
+		if oh.NumberOfRvaAndSizes > peExportDirectoryIndex {
+			exportDirectory = oh.DataDirectory[peExportDirectoryIndex]
+		}
+	}
+	if exportDirectory.VirtualAddress == 0 || exportDirectory.Size < 40 {
+		return nil
+	}
+	directory := peReadRVA(peFile, exportDirectory.VirtualAddress, 40)
+	if directory == nil {
+		return nil
+	}
+	numberOfNames := binary.LittleEndian.Uint32(directory[24:28])
+	addressOfNames := binary.LittleEndian.Uint32(directory[32:36])
+	// Guard against corrupted directories claiming an absurd number of exports.
+	if numberOfNames > 0x10000 {
+		return nil
+	}
+	nameAddresses := peReadRVA(peFile, addressOfNames, numberOfNames*4)
+	if nameAddresses == nil {
+		return nil
+	}
+	names := []string{}
+	for i := uint32(0); i < numberOfNames; i++ {
+		nameAddress := binary.LittleEndian.Uint32(nameAddresses[i*4 : i*4+4])
+		name := peReadRVA(peFile, nameAddress, 1)
+		if name == nil {
+			continue
+		}
+		// peReadRVA returns the remainder of the section, so trim to the null terminator.
+		if end := bytes.IndexByte(name, 0); end >= 0 {
+			name = name[:end]
+		}
+		names = append(names, string(name))
+	}
+	return names
+}
+
+// Read at least size bytes from the section containing the relative virtual address.
+// The rest of the section after the address is returned, or nil if it can't be read.
+func peReadRVA(peFile *pe.File, rva uint32, size uint32) []byte {
+	for _, section := range peFile.Sections {
+		sectionSize := max(section.VirtualSize, section.Size)
+		if rva < section.VirtualAddress || rva >= section.VirtualAddress+sectionSize {
+			continue
+		}
+		data, err := section.Data()
+		if err != nil {
+			return nil
+		}
+		offset := rva - section.VirtualAddress
+		if uint64(offset)+uint64(size) > uint64(len(data)) {
+			return nil
+		}
+		return data[offset:]
+	}
+	return nil
+}
+
+func machoBuildMode(rawFile *os.File) (string, string, []string) {
+	machoFile, err := macho.NewFile(rawFile)
+	if err != nil {
+		// Universal binaries hold one Mach-O per architecture, use the first one.
+		fatFile, fatErr := macho.NewFatFile(rawFile)
+		if fatErr != nil || len(fatFile.Arches) == 0 {
+			return "", "", nil
+		}
+		defer fatFile.Close()
+		machoFile = fatFile.Arches[0].File
+	} else {
+		defer machoFile.Close()
+	}
+	return machoFileBuildMode(machoFile)
+}
+
+func machoFileBuildMode(machoFile *macho.File) (string, string, []string) {
+	symbolNames := []string{}
+	exportNames := []string{}
+	if machoFile.Symtab != nil {
+		for _, symbol := range machoFile.Symtab.Syms {
+			// Mach-O prefixes C symbols with an underscore.
+			name := strings.TrimPrefix(symbol.Name, "_")
+			symbolNames = append(symbolNames, name)
+			// N_EXT (0x01) marks external symbols and N_SECT (0x0e) those defined in a section.
+			if symbol.Type&0x01 != 0 && symbol.Type&0x0e == 0x0e {
+				exportNames = append(exportNames, name)
+			}
+		}
+	}
+
+	buildMode := ""
+	switch machoFile.Type {
+	case macho.TypeExec:
+		buildMode = "exe"
+		if machoFile.Flags&macho.FlagPIE != 0 {
+			buildMode = "pie"
+		}
+	case macho.TypeDylib, macho.TypeBundle:
+		buildMode = "c-shared"
+		if isGoPlugin(symbolNames) {
+			buildMode = "plugin"
+		}
+	case macho.TypeObj:
+		buildMode = "c-archive"
+	}
+
+	// The Go linker doesn't generate compact unwind information.
+	linkMode := "internal"
+	if machoFile.Section("__unwind_info") != nil || machoFile.Section("__eh_frame") != nil {
+		linkMode = "external"
+	}
+	return buildMode, linkMode, filterCgoExports(exportNames)
+}
+
+// Add the build mode, link mode and cgo export features.
+func addBuildModeFeatures(features *featureWriter, buildModeInfo *goBuildModeInfo) {
+	if features.report != nil {
+		features.report.BuildMode = buildModeInfo.buildMode
+		features.report.LinkMode = buildModeInfo.linkMode
+	}
+	if buildModeInfo.buildMode != "" {
+		features.addFeatureWithExtra("go_buildmode", buildModeInfo.buildMode, &featureOptions{
+			Label: buildModeInfo.buildModeSource,
+		})
+	}
+	if buildModeInfo.linkMode != "" {
+		features.addFeatureWithExtra("go_linkmode", buildModeInfo.linkMode, &featureOptions{
+			Label: buildModeInfo.linkModeSource,
+		})
+	}
+	for _, cgoExport := range buildModeInfo.cgoExports {
+		features.addFeature("go_cgo_export", cgoExport)
+	}
+}
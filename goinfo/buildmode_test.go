@@ -0,0 +1,104 @@
+package goinfo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// A minimal Go program used when the contents of the test binary don't matter.
+const helloWorldSource = "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"
+
+// Compile a Go program in dir with the local toolchain and return the path to the binary.
+func compileTestProgram(dir string, source string, env []string, args ...string) (string, error) {
+	err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(source), 0o600)
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/hello\n\ngo 1.21\n"), 0o600)
+	if err != nil {
+		return "", err
+	}
+	outPath := filepath.Join(dir, "hello.bin")
+	cmd := exec.Command("go", append(append([]string{"build"}, args...), "-o", outPath, ".")...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "CGO_ENABLED=0", "GOFLAGS="), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w %s", err, output)
+	}
+	return outPath, nil
+}
+
+// Compile a Go program with the local toolchain and return the path to the binary.
+// The test is skipped if the toolchain can't build for the requested environment.
+func buildTestProgram(t *testing.T, source string, env []string, args ...string) string {
+	t.Helper()
+	outPath, err := compileTestProgram(t.TempDir(), source, env, args...)
+	if err != nil {
+		t.Skipf("unable to build test program with local toolchain: %v", err)
+	}
+	return outPath
+}
+
+func TestDetectBuildModeHeuristics(t *testing.T) {
+	testCases := []struct {
+		goos      string
+		goarch    string
+		buildMode string
+	}{
+		{"linux", "amd64", "exe"},
+		{"linux", "amd64", "pie"},
+		{"windows", "amd64", "exe"},
+		{"windows", "amd64", "pie"},
+		{"darwin", "arm64", "pie"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.goos+"_"+tc.goarch+"_"+tc.buildMode, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch}, "-buildmode="+tc.buildMode)
+			// No build settings so only the file headers are used.
+			info := detectBuildMode(binaryPath, map[string]string{})
+			if info.buildMode != tc.buildMode || info.buildModeSource != modeSourceHeuristic {
+				t.Errorf("expected build mode %s from heuristics, got %s from %s", tc.buildMode, info.buildMode, info.buildModeSource)
+			}
+			if info.linkMode != "internal" || info.linkModeSource != modeSourceHeuristic {
+				t.Errorf("expected internal link mode from heuristics, got %s from %s", info.linkMode, info.linkModeSource)
+			}
+		})
+	}
+}
+
+func TestDetectBuildModeStrippedCShared(t *testing.T) {
+	source := "package main\n\nimport \"C\"\n\n//export Run\nfunc Run() {}\n\nfunc main() {}\n"
+	for _, ldflags := range []string{"-ldflags=-s", "-ldflags=-s -w"} {
+		t.Run(ldflags, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, source, []string{"CGO_ENABLED=1", "GOOS=linux", "GOARCH=amd64"}, "-buildmode=c-shared", ldflags)
+			info := detectBuildMode(binaryPath, map[string]string{})
+			if info.buildMode != "c-shared" || info.buildModeSource != modeSourceHeuristic {
+				t.Errorf("expected c-shared build mode from heuristics, got %s from %s", info.buildMode, info.buildModeSource)
+			}
+		})
+	}
+}
+
+func TestDetectBuildModeSettings(t *testing.T) {
+	info := detectBuildMode(filepath.Join(t.TempDir(), "missing"), map[string]string{
+		"-buildmode": "c-shared",
+		"-ldflags":   "-s -w -linkmode=external -extldflags '-static'",
+	})
+	if info.buildMode != "c-shared" || info.buildModeSource != modeSourceBuildSetting {
+		t.Errorf("expected c-shared build mode from build settings, got %s from %s", info.buildMode, info.buildModeSource)
+	}
+	if info.linkMode != "external" || info.linkModeSource != modeSourceBuildSetting {
+		t.Errorf("expected external link mode from build settings, got %s from %s", info.linkMode, info.linkModeSource)
+	}
+}
+
+func TestFilterCgoExports(t *testing.T) {
+	exports := filterCgoExports([]string{"RunPayload", "_cgo_panic", "_rt0_amd64_windows_lib", "main.main", "DllMain", "RunPayload", "x_cgo_init"})
+	if len(exports) != 2 || exports[0] != "DllMain" || exports[1] != "RunPayload" {
+		t.Errorf("unexpected exports %v", exports)
+	}
+}
@@ -0,0 +1,319 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/gosym"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Labels used to show what evidence was used to decide a binary uses cgo.
+const (
+	cgoEvidenceBuildSetting   = "build_setting"
+	cgoEvidenceStub           = "cgo_stub"
+	cgoEvidenceRuntime        = "runtime_cgo"
+	cgoEvidenceExternalImport = "external_import"
+)
+
+// Build settings holding the flags passed to the C compiler and linker. Go records them, often empty, whenever
+// cgo is enabled, and flags that were set show the build compiled or linked C code of its own. Each one that
+// is set is used as evidence labelled with its lower case name, e.g. cgo_ldflags.
+var cgoFlagSettings = []string{"CGO_CFLAGS", "CGO_CPPFLAGS", "CGO_CXXFLAGS", "CGO_LDFLAGS"}
+
+// Matches the Go and C side stubs cgo generates for each C function, variable or macro referenced from Go.
+// e.g 'main._Cfunc_puts' and '_cgo_0b49a3ff7a4c_Cfunc_puts'
+var cgoStubRegex = regexp.MustCompile(`_C(?:func|macro)_([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// Matches the Go files cgo generates from a source file that imports "C".
+var cgoGeneratedFileRegex = regexp.MustCompile(`(\.cgo[12]\.go|^_cgo_gotypes\.go|^_cgo_import\.go)$`)
+
+// Matches source file names for languages cgo can compile alongside Go.
+// Assembly is left out as the Go toolchain has its own assembly files in every binary.
+var cgoSourceFileRegex = regexp.MustCompile(`\.(c|cc|cpp|cxx|m|mm|h|hh|hpp)$`)
+
+// Source files from the C runtime start files that are in every externally linked binary.
+var cgoToolchainFiles = map[string]struct{}{
+	"crtstuff.c": {},
+	"crt1.c":     {},
+	"crti.c":     {},
+	"crtn.c":     {},
+}
+
+// Helper functions cgo generates for converting between Go and C values, these are in every cgo package.
+var cgoHelperFunctions = map[string]struct{}{
+	"GoString":  {},
+	"GoStringN": {},
+	"GoBytes":   {},
+	"CString":   {},
+	"CBytes":    {},
+}
+
+// Libraries a Go binary imports even when it was built without cgo.
+var cgoBaselineLibraries = map[string]struct{}{
+	// Windows runtime.
+	"kernel32.dll": {},
+	// Darwin runtime, net and crypto/x509.
+	"libsystem.b.dylib": {},
+	"libresolv.9.dylib": {},
+	"corefoundation":    {},
+	"security":          {},
+}
+
+// A C function called from Go code.
+type cgoFunction struct {
+	name    string
+	library string
+}
+
+// The cgo usage of a Go binary.
+type goCgoInfo struct {
+	enabled bool
+	// CGO_ENABLED=0 was set at build time and nothing contradicted it.
+	disabled  bool
+	evidence  []string
+	functions []cgoFunction
+	libraries []string
+	files     []string
+}
+
+// Records a piece of evidence that the binary uses cgo, ignoring duplicates.
+func (ci *goCgoInfo) addEvidence(evidence string) {
+	ci.enabled = true
+	for _, existing := range ci.evidence {
+		if existing == evidence {
+			return
+		}
+	}
+	ci.evidence = append(ci.evidence, evidence)
+}
+
+// Returns true if the library is imported by the Go runtime regardless of cgo.
+func isCgoBaselineLibrary(library string) bool {
+	library = strings.ToLower(path.Base(library))
+	_, ok := cgoBaselineLibraries[library]
+	return ok
+}
+
+// Detect whether a Go binary links C code and which C functions, libraries and files are involved.
+func detectCgo(filePath string, buildSettings map[string]string, pclntab *gosym.Table) *goCgoInfo {
+	info := &goCgoInfo{}
+	functions := map[cgoFunction]struct{}{}
+	libraries := map[string]struct{}{}
+	files := map[string]struct{}{}
+
+	cgoEnabledSetting, hasCgoEnabledSetting := buildSettings["CGO_ENABLED"]
+	if cgoEnabledSetting == "1" {
+		info.addEvidence(cgoEvidenceBuildSetting)
+	}
+	for _, flagSetting := range cgoFlagSettings {
+		if strings.TrimSpace(buildSettings[flagSetting]) != "" {
+			info.addEvidence(strings.ToLower(flagSetting))
+		}
+	}
+
+	addStub := func(symbolName string) {
+		match := cgoStubRegex.FindStringSubmatch(symbolName)
+		if match == nil {
+			return
+		}
+		info.addEvidence(cgoEvidenceStub)
+		// Names starting with _C are cgo's own allocation helpers.
+		if _, ok := cgoHelperFunctions[match[1]]; ok || strings.HasPrefix(match[1], "_C") {
+			return
+		}
+		functions[cgoFunction{name: match[1]}] = struct{}{}
+	}
+	addFile := func(fileName string, fromSymbolTable bool) {
+		baseName := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+		// runtime/cgo is linked into every cgo binary so its files say nothing about the sample.
+		if strings.Contains(fileName, "runtime/cgo") || strings.HasPrefix(baseName, "gcc_") {
+			return
+		}
+		if _, ok := cgoToolchainFiles[baseName]; ok {
+			return
+		}
+		// The pclntab only holds Go files, C files are only named in the symbol table.
+		if cgoGeneratedFileRegex.MatchString(baseName) || (fromSymbolTable && cgoSourceFileRegex.MatchString(baseName)) {
+			files[baseName] = struct{}{}
+		}
+	}
+
+	if pclntab != nil {
+		for _, function := range pclntab.Funcs {
+			addStub(function.Name)
+			if strings.HasPrefix(function.Name, "runtime/cgo.") {
+				info.addEvidence(cgoEvidenceRuntime)
+			}
+			fileName, _, _ := pclntab.PCToLine(function.Entry)
+			if fileName != "" {
+				addFile(fileName, false)
+			}
+		}
+	}
+
+	symbolNames, importedSymbols := cgoFileSymbols(filePath, libraries, addFile)
+	for _, symbolName := range symbolNames {
+		addStub(symbolName)
+		// Only the C side of runtime/cgo is checked, the Go runtime always has a _cgo_init variable.
+		if symbolName == "x_cgo_init" {
+			info.addEvidence(cgoEvidenceRuntime)
+		}
+	}
+	for _, importedSymbol := range importedSymbols {
+		if !isCgoBaselineLibrary(importedSymbol.library) {
+			info.addEvidence(cgoEvidenceExternalImport)
+		}
+	}
+	for library := range libraries {
+		if isCgoBaselineLibrary(library) {
+			delete(libraries, library)
+		}
+	}
+
+	if !info.enabled {
+		info.disabled = hasCgoEnabledSetting && cgoEnabledSetting == "0"
+		return info
+	}
+
+	// Only list imports once cgo is known to be in use, otherwise they are just the Go runtime's.
+	for _, importedSymbol := range importedSymbols {
+		if !isCgoBaselineLibrary(importedSymbol.library) {
+			functions[importedSymbol] = struct{}{}
+		}
+	}
+	for function := range functions {
+		info.functions = append(info.functions, function)
+	}
+	sort.Slice(info.functions, func(i, j int) bool {
+		if info.functions[i].name != info.functions[j].name {
+			return info.functions[i].name < info.functions[j].name
+		}
+		return info.functions[i].library < info.functions[j].library
+	})
+	for library := range libraries {
+		info.libraries = append(info.libraries, library)
+	}
+	sort.Strings(info.libraries)
+	for file := range files {
+		info.files = append(info.files, file)
+	}
+	sort.Strings(info.files)
+	return info
+}
+
+// Read the symbol names and imported C functions from the executable's headers.
+// Imported libraries are added to the libraries set and source file symbols passed to addFile.
+func cgoFileSymbols(filePath string, libraries map[string]struct{}, addFile func(string, bool)) ([]string, []cgoFunction) {
+	rawFile, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil
+	}
+	defer rawFile.Close()
+	magic := make([]byte, 4)
+	_, err = rawFile.ReadAt(magic, 0)
+	if err != nil {
+		return nil, nil
+	}
+
+	symbolNames := []string{}
+	imports := []cgoFunction{}
+	switch {
+	case bytes.HasPrefix(magic, []byte(elf.ELFMAG)):
+		elfFile, err := elf.NewFile(rawFile)
+		if err != nil {
+			return nil, nil
+		}
+		symbols, _ := elfFile.Symbols()
+		for _, symbol := range symbols {
+			if elf.ST_TYPE(symbol.Info) == elf.STT_FILE {
+				addFile(symbol.Name, true)
+				continue
+			}
+			symbolNames = append(symbolNames, symbol.Name)
+		}
+		importedSymbols, _ := elfFile.ImportedSymbols()
+		for _, importedSymbol := range importedSymbols {
+			imports = append(imports, cgoFunction{name: importedSymbol.Name, library: importedSymbol.Library})
+		}
+		importedLibraries, _ := elfFile.ImportedLibraries()
+		for _, library := range importedLibraries {
+			libraries[library] = struct{}{}
+		}
+	case bytes.HasPrefix(magic, []byte("MZ")):
+		peFile, err := pe.NewFile(rawFile)
+		if err != nil {
+			return nil, nil
+		}
+		for _, symbol := range peFile.Symbols {
+			symbolNames = append(symbolNames, symbol.Name)
+		}
+		// PE imports are formatted as 'function:library'.
+		importedSymbols, _ := peFile.ImportedSymbols()
+		for _, importedSymbol := range importedSymbols {
+			name, library, _ := strings.Cut(importedSymbol, ":")
+			imports = append(imports, cgoFunction{name: name, library: strings.ToLower(library)})
+		}
+		importedLibraries, _ := peFile.ImportedLibraries()
+		for _, library := range importedLibraries {
+			libraries[strings.ToLower(library)] = struct{}{}
+		}
+	default:
+		machoFile, err := macho.NewFile(rawFile)
+		if err != nil {
+			fatFile, fatErr := macho.NewFatFile(rawFile)
+			if fatErr != nil || len(fatFile.Arches) == 0 {
+				return nil, nil
+			}
+			machoFile = fatFile.Arches[0].File
+		}
+		if machoFile.Symtab != nil {
+			for _, symbol := range machoFile.Symtab.Syms {
+				symbolNames = append(symbolNames, strings.TrimPrefix(symbol.Name, "_"))
+			}
+		}
+		// Mach-O two level namespace imports don't record the library per symbol in the symbol table.
+		importedSymbols, _ := machoFile.ImportedSymbols()
+		importedLibraries, _ := machoFile.ImportedLibraries()
+		nonBaselineLibrary := ""
+		for _, library := range importedLibraries {
+			libraries[library] = struct{}{}
+			if !isCgoBaselineLibrary(library) {
+				nonBaselineLibrary = library
+			}
+		}
+		// Without a non runtime library the imports are just the Go runtime's use of libSystem.
+		if nonBaselineLibrary != "" {
+			for _, importedSymbol := range importedSymbols {
+				imports = append(imports, cgoFunction{name: strings.TrimPrefix(importedSymbol, "_")})
+			}
+		}
+	}
+	return symbolNames, imports
+}
+
+// Add the cgo usage and inventory features.
+func addCgoFeatures(features *featureWriter, cgoInfo *goCgoInfo) {
+	if cgoInfo.enabled || cgoInfo.disabled {
+		features.addFeatureWithExtra("go_cgo_enabled", fmt.Sprintf("%t", cgoInfo.enabled), &featureOptions{
+			Label: strings.Join(cgoInfo.evidence, ","),
+		})
+	}
+	for _, cgoFunc := range cgoInfo.functions {
+		features.addFeatureWithExtra("go_cgo_function", cgoFunc.name, &featureOptions{
+			Label: cgoFunc.library,
+		})
+	}
+	for _, cgoLibrary := range cgoInfo.libraries {
+		features.addFeature("go_cgo_library", cgoLibrary)
+	}
+	for _, cgoFile := range cgoInfo.files {
+		features.addFeature("go_cgo_file", cgoFile)
+	}
+}
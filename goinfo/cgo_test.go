@@ -0,0 +1,122 @@
+package goinfo
+
+import (
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/gosym"
+	"slices"
+	"testing"
+)
+
+const cgoTestSource = `package main
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+
+static void greet(const char *name) {
+	printf("hello %s\n", name);
+}
+*/
+import "C"
+import "unsafe"
+
+func main() {
+	name := C.CString("world")
+	defer C.free(unsafe.Pointer(name))
+	C.greet(name)
+}
+`
+
+// Read the pclntab from an ELF binary the same way gore does.
+func readElfPclntab(t *testing.T, binaryPath string) *gosym.Table {
+	t.Helper()
+	elfFile, err := elf.Open(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer elfFile.Close()
+	pclntabData, err := elfFile.Section(".gopclntab").Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(pclntabData, elfFile.Section(".text").Addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestDetectCgo(t *testing.T) {
+	binaryPath := buildTestProgram(t, cgoTestSource, []string{"CGO_ENABLED=1", "GOOS=linux", "GOARCH=amd64"})
+	info := detectCgo(binaryPath, map[string]string{"CGO_ENABLED": "1"}, readElfPclntab(t, binaryPath))
+
+	if !info.enabled {
+		t.Fatalf("expected cgo to be detected")
+	}
+	for _, evidence := range []string{cgoEvidenceBuildSetting, cgoEvidenceStub, cgoEvidenceRuntime, cgoEvidenceExternalImport} {
+		found := false
+		for _, actual := range info.evidence {
+			found = found || actual == evidence
+		}
+		if !found {
+			t.Errorf("expected evidence %s in %v", evidence, info.evidence)
+		}
+	}
+	foundGreet, foundFree := false, false
+	for _, function := range info.functions {
+		foundGreet = foundGreet || function.name == "greet"
+		foundFree = foundFree || function.name == "free"
+	}
+	if !foundGreet || !foundFree {
+		t.Errorf("expected C functions greet and free in %v", info.functions)
+	}
+	expectedFiles := []string{"_cgo_export.c", "_cgo_gotypes.go", "main.cgo2.c"}
+	for _, expectedFile := range expectedFiles {
+		found := false
+		for _, file := range info.files {
+			found = found || file == expectedFile
+		}
+		if !found {
+			t.Errorf("expected cgo file %s in %v", expectedFile, info.files)
+		}
+	}
+	if len(info.libraries) == 0 {
+		t.Errorf("expected imported C libraries")
+	}
+}
+
+func TestDetectCgoFlagSettings(t *testing.T) {
+	binaryPath := buildTestProgram(t, cgoTestSource, []string{"CGO_ENABLED=1", "GOOS=linux", "GOARCH=amd64", "CGO_CFLAGS=-O2 -DAGENT", "CGO_LDFLAGS=-lm", "CGO_CPPFLAGS=", "CGO_CXXFLAGS="})
+	buildInfo, err := buildinfo.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildSettings := map[string]string{}
+	for _, setting := range buildInfo.Settings {
+		buildSettings[setting.Key] = setting.Value
+	}
+	info := detectCgo(binaryPath, buildSettings, readElfPclntab(t, binaryPath))
+	for _, evidence := range []string{cgoEvidenceBuildSetting, "cgo_cflags", "cgo_ldflags"} {
+		if !slices.Contains(info.evidence, evidence) {
+			t.Errorf("expected evidence %s in %v", evidence, info.evidence)
+		}
+	}
+	// Flags recorded empty aren't evidence of anything.
+	for _, evidence := range []string{"cgo_cppflags", "cgo_cxxflags"} {
+		if slices.Contains(info.evidence, evidence) {
+			t.Errorf("unexpected evidence %s in %v", evidence, info.evidence)
+		}
+	}
+}
+
+func TestDetectCgoDisabled(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=linux", "GOARCH=amd64"})
+	info := detectCgo(binaryPath, map[string]string{"CGO_ENABLED": "0"}, readElfPclntab(t, binaryPath))
+	if info.enabled || !info.disabled {
+		t.Errorf("expected cgo to be disabled, got evidence %v", info.evidence)
+	}
+	if len(info.functions) != 0 || len(info.libraries) != 0 || len(info.files) != 0 {
+		t.Errorf("expected no cgo inventory, got %v %v %v", info.functions, info.libraries, info.files)
+	}
+}
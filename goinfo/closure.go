@@ -0,0 +1,102 @@
+package goinfo
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Closure parts the compiler names after how the closure is used, e.g. 'func1', a goroutine body 'gowrap1'
+// or a deferred call 'deferwrap1'. Closures nested in another closure only get a number, e.g. 'func2.1'.
+var closureKindPattern = regexp.MustCompile(`^(func|gowrap|deferwrap)\d+$`)
+
+// A closure with the code range of its function.
+type closureFunction struct {
+	name   string
+	parent string
+	entry  uint64
+	end    uint64
+}
+
+// Closures found in a binary, grouped by the top level function they are nested in.
+type closureTree struct {
+	closures []closureFunction
+	// Number of closures nested in each top level function, however deep.
+	counts map[string]int
+}
+
+// Name of a function qualified by its package and receiver, e.g. 'main.(*Agent).beacon'.
+// Generic receivers lose their type arguments so every instantiation has the same name.
+func qualifiedFunctionName(symbol goSymbol, name string) string {
+	qualified := symbol.packagePath + "."
+	if symbol.receiver != "" {
+		if symbol.pointerReceiver {
+			qualified += "(*" + symbol.receiver + ")."
+		} else {
+			qualified += symbol.receiver + "."
+		}
+	}
+	return qualified + name
+}
+
+// Record a function if it is a closure, with the function it is declared in as its parent.
+// Numbered init functions such as 'init.0' aren't closures but can have closures of their own.
+func (c *closureTree) add(symbol goSymbol, entry uint64, end uint64) {
+	if symbol.closure == "" {
+		return
+	}
+	parts := strings.Split(symbol.closure, ".")
+	top := symbol.name
+	for len(parts) > 0 && !closureKindPattern.MatchString(parts[0]) {
+		if _, err := strconv.Atoi(parts[0]); err != nil {
+			return
+		}
+		top += "." + parts[0]
+		parts = parts[1:]
+	}
+	if len(parts) == 0 {
+		return
+	}
+	parent := qualifiedFunctionName(symbol, top)
+	topLevel := parent
+	for _, part := range parts[:len(parts)-1] {
+		parent += "." + part
+	}
+	if c.counts == nil {
+		c.counts = map[string]int{}
+	}
+	c.counts[topLevel]++
+	c.closures = append(c.closures, closureFunction{
+		name:   parent + "." + parts[len(parts)-1],
+		parent: parent,
+		entry:  entry,
+		end:    end,
+	})
+}
+
+// Top level functions with closures sorted by name.
+func (c *closureTree) parents() []string {
+	names := make([]string, 0, len(c.counts))
+	for name := range c.counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add each closure labelled with the function it is declared in, and the number of closures in each top level function.
+func addClosureFeatures(features *featureWriter, closures *closureTree) {
+	for _, closure := range closures.closures {
+		features.addFeatureWithExtra("go_closure", closure.name, &featureOptions{
+			Label:  closure.parent,
+			Offset: closure.entry,
+			Size:   closure.end - closure.entry,
+		})
+	}
+	for _, parent := range closures.parents() {
+		features.addFeatureWithExtra("go_function_closure_count", strconv.Itoa(closures.counts[parent]), &featureOptions{
+			Label: parent,
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package goinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClosureTree(t *testing.T) {
+	closures := &closureTree{}
+	for _, symbol := range []string{
+		"main.main",
+		"main.main.func1",
+		"main.run.func2",
+		"main.run.func2.1",
+		"main.(*Agent).beacon.gowrap3",
+		"main.(*Agent).beacon.deferwrap1",
+		"main.init.0",
+		"main.init.0.func1",
+		"example.com/list.(*List[go.shape.int]).Each.func1",
+	} {
+		closures.add(parseGoSymbol(symbol), 0x1000, 0x1010)
+	}
+
+	expected := []closureFunction{
+		{name: "main.main.func1", parent: "main.main"},
+		{name: "main.run.func2", parent: "main.run"},
+		{name: "main.run.func2.1", parent: "main.run.func2"},
+		{name: "main.(*Agent).beacon.gowrap3", parent: "main.(*Agent).beacon"},
+		{name: "main.(*Agent).beacon.deferwrap1", parent: "main.(*Agent).beacon"},
+		{name: "main.init.0.func1", parent: "main.init.0"},
+		{name: "example.com/list.(*List).Each.func1", parent: "example.com/list.(*List).Each"},
+	}
+	if len(closures.closures) != len(expected) {
+		t.Fatalf("expected %d closures got %+v", len(expected), closures.closures)
+	}
+	for i, closure := range closures.closures {
+		if closure.name != expected[i].name || closure.parent != expected[i].parent {
+			t.Errorf("expected closure %s of %s got %s of %s", expected[i].name, expected[i].parent, closure.name, closure.parent)
+		}
+	}
+
+	expectedParents := []string{"example.com/list.(*List).Each", "main.(*Agent).beacon", "main.init.0", "main.main", "main.run"}
+	if parents := closures.parents(); !slices.Equal(parents, expectedParents) {
+		t.Fatalf("expected parents %v got %v", expectedParents, parents)
+	}
+	if count := closures.counts["main.run"]; count != 2 {
+		t.Errorf("expected 2 closures in main.run got %d", count)
+	}
+}
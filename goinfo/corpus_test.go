@@ -0,0 +1,160 @@
+package goinfo
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Program compiled for every corpus target, with methods, generics, closures and a goroutine to find.
+const corpusSource = `package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+type Agent struct {
+	name string
+}
+
+//go:noinline
+func (a *Agent) beacon(wg *sync.WaitGroup) {
+	go func() {
+		defer wg.Done()
+		fmt.Println(a.name)
+	}()
+}
+
+//go:noinline
+func (a Agent) String() string {
+	return a.name
+}
+
+//go:noinline
+func Map[T, U any](values []T, f func(T) U) []U {
+	mapped := make([]U, 0, len(values))
+	for _, value := range values {
+		mapped = append(mapped, f(value))
+	}
+	return mapped
+}
+
+func main() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	agent := &Agent{name: os.Args[0]}
+	agent.beacon(&wg)
+	wg.Wait()
+	lengths := Map(os.Args, func(s string) int { return len(s) })
+	names := Map(lengths, func(n int) string { return fmt.Sprint(n) })
+	defer func() {
+		fmt.Println(names, agent.String())
+	}()
+}
+`
+
+// Sorted distinct values of a feature, with the label after a '|' when it has one.
+func featureValues(report *Report, name string) []string {
+	values := []string{}
+	for _, feature := range report.Features {
+		if feature.Name != name {
+			continue
+		}
+		value := feature.Value
+		if feature.Label != "" {
+			value += "|" + feature.Label
+		}
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return slices.Compact(values)
+}
+
+// Features every build of the corpus program has, whatever the target.
+var corpusFeatures = map[string][]string{
+	"go_package":     {"main"},
+	"go_cgo_enabled": {"false"},
+	"go_closure": {
+		"main.(*Agent).beacon.func1.deferwrap1|main.(*Agent).beacon.func1",
+		"main.(*Agent).beacon.func1|main.(*Agent).beacon",
+		"main.main.func1|main.main",
+		"main.main.func2|main.main",
+		"main.main.func3|main.main",
+	},
+	"go_function_closure_count":      {"2|main.(*Agent).beacon", "3|main.main"},
+	"go_generic_instantiation_count": {"2|main.Map"},
+	"go_method_receiver":             {"main.Agent|pointer", "main.Agent|value"},
+}
+
+// Compile the corpus program for a spread of platforms and build options and check the analysis of each.
+func TestCorpus(t *testing.T) {
+	testCases := []struct {
+		goos      string
+		goarch    string
+		args      []string
+		buildMode string
+	}{
+		{"linux", "amd64", nil, "exe"},
+		{"linux", "386", nil, "exe"},
+		{"linux", "arm64", nil, "exe"},
+		{"linux", "arm", nil, "exe"},
+		{"linux", "riscv64", nil, "exe"},
+		{"linux", "amd64", []string{"-buildmode=pie"}, "pie"},
+		{"linux", "amd64", []string{"-trimpath"}, "exe"},
+		{"linux", "amd64", []string{"-ldflags=-s -w"}, "exe"},
+		{"windows", "amd64", nil, "exe"},
+		{"windows", "386", nil, "exe"},
+		{"windows", "arm64", nil, "exe"},
+		{"darwin", "amd64", nil, "exe"},
+		// The linker defaults to PIE on darwin/arm64 but the build setting still says exe.
+		{"darwin", "arm64", nil, "exe"},
+		{"freebsd", "amd64", nil, "exe"},
+	}
+	for _, tc := range testCases {
+		name := tc.goos + "_" + tc.goarch + strings.ReplaceAll(strings.Join(tc.args, "_"), " ", "")
+		t.Run(name, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, corpusSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch}, tc.args...)
+			report, err := NewAnalyzer(nil).Analyze(context.Background(), binaryPath)
+			if err != nil {
+				t.Fatalf("analysis failed %v", err)
+			}
+			if report.OptOut != "" {
+				t.Fatalf("unexpected opt out %s", report.OptOut)
+			}
+
+			expected := map[string][]string{"go_buildmode": {tc.buildMode + "|build_setting"}}
+			for name, values := range corpusFeatures {
+				expected[name] = values
+			}
+			for name, values := range expected {
+				if actual := featureValues(report, name); !slices.Equal(actual, values) {
+					t.Errorf("expected %s to be %v got %v", name, values, actual)
+				}
+			}
+			flags := featureValues(report, "go_compiler_flag")
+			for _, flag := range []string{tc.goos + "|GOOS", tc.goarch + "|GOARCH", "0|CGO_ENABLED"} {
+				if !slices.Contains(flags, flag) {
+					t.Errorf("expected go_compiler_flag %s in %v", flag, flags)
+				}
+			}
+			if generics := featureValues(report, "go_generic_function"); len(generics) != 1 || !strings.HasPrefix(generics[0], "main.Map|") {
+				t.Errorf("expected go_generic_function main.Map got %v", generics)
+			}
+			// The corpus is built with the toolchain running the tests, which gore may not know yet.
+			if versions := featureValues(report, "go_compiler_version"); !slices.Equal(versions, []string{runtime.Version()}) {
+				t.Errorf("expected go_compiler_version %s got %v", runtime.Version(), versions)
+			}
+			if len(report.Binaries) != 1 {
+				t.Fatalf("expected a binary report got %d", len(report.Binaries))
+			}
+			if report.Binaries[0].GoVersion != runtime.Version() {
+				t.Errorf("expected go version %s got %s", runtime.Version(), report.Binaries[0].GoVersion)
+			}
+		})
+	}
+}
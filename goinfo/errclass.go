@@ -0,0 +1,159 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/goretk/gore"
+)
+
+// Category of a failure to parse a file, it decides how the analysis reports the failure.
+type errorCategory string
+
+const (
+	// The file isn't an executable format that gore understands, e.g. a document or a memory dump.
+	errorNotExecutable errorCategory = "not_executable"
+	// The file is a valid executable but wasn't built by Go.
+	errorNotGo errorCategory = "not_go"
+	// The file's headers or tables are damaged, the Go metadata can often still be found without them.
+	errorCorrupted errorCategory = "corrupted"
+	// Gore can't disassemble the architecture, only architecture independent metadata is available.
+	errorUnsupportedArch errorCategory = "unsupported_arch"
+	// A failure that doesn't match a known cause, such as a panic in gore.
+	errorInternalBug errorCategory = "internal_bug"
+)
+
+// How the analysis reports a failure.
+type errorOutcome int
+
+const (
+	// The analysis opts out of the file.
+	outcomeOptOut errorOutcome = iota
+	// The failure is recorded as a feature and the rest of the Go metadata is still extracted.
+	outcomeFeature
+	// The analysis fails with an error.
+	outcomeError
+)
+
+// How the analysis reports failures of this category.
+func (c errorCategory) outcome() errorOutcome {
+	switch c {
+	case errorNotExecutable, errorNotGo:
+		return outcomeOptOut
+	case errorCorrupted, errorUnsupportedArch:
+		return outcomeFeature
+	}
+	return outcomeError
+}
+
+// A failure to parse a file along with its category.
+type classifiedError struct {
+	category errorCategory
+	// Set when only the COFF symbol or string table of a PE file is damaged, which can be removed.
+	peSymbolTable bool
+	err           error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// Messages of the errors debug/buildinfo returns for files that aren't executables and executables that aren't Go.
+// They are unexported, so they can only be recognised by their message.
+const (
+	buildInfoUnrecognizedFormatMessage = "unrecognized file format"
+	buildInfoNotGoExecutableMessage    = "not a Go executable"
+)
+
+// Check whether err wraps the debug/buildinfo error with the message.
+func isBuildInfoError(err error, message string) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err.Error() == message {
+			return true
+		}
+	}
+	return false
+}
+
+// How much of a PE file debug/pe can't parse.
+type peDamage int
+
+const (
+	// The file parses, or isn't a PE file.
+	peUndamaged peDamage = iota
+	// The file only parses once its COFF symbol table is removed.
+	peSymbolTableDamaged
+	// The file doesn't parse even without its COFF symbol table.
+	peHeadersDamaged
+)
+
+// Find how a PE file is damaged by parsing it with debug/pe.
+// Debug/pe has no error types, so the damage is found from what it can parse rather than from its messages.
+func checkPEDamage(data []byte) peDamage {
+	if !bytes.HasPrefix(data, []byte("MZ")) {
+		return peUndamaged
+	}
+	if _, err := pe.NewFile(bytes.NewReader(data)); err == nil {
+		return peUndamaged
+	}
+	repaired := bytes.Clone(data)
+	if removePESymbolTable(repaired) == nil {
+		if _, err := pe.NewFile(bytes.NewReader(repaired)); err == nil {
+			return peSymbolTableDamaged
+		}
+	}
+	return peHeadersDamaged
+}
+
+// The panic gore raises when it can't disassemble the architecture of a file.
+const goreUnsupportedArchPanic = "Unsupported architecture"
+
+// Classify an error returned while parsing a file with gore, debug/elf, debug/macho or debug/buildinfo.
+func classifyError(err error) *classifiedError {
+	classified := &classifiedError{category: errorInternalBug, err: err}
+	var panicErr *gorePanicError
+	var elfErr *elf.FormatError
+	var machoErr *macho.FormatError
+	switch {
+	case errors.As(err, &panicErr):
+		if panicErr.message == goreUnsupportedArchPanic {
+			classified.category = errorUnsupportedArch
+		}
+	case errors.Is(err, gore.ErrUnsupportedFile), isBuildInfoError(err, buildInfoUnrecognizedFormatMessage):
+		classified.category = errorNotExecutable
+	case errors.Is(err, gore.ErrNoGoVersionFound), isBuildInfoError(err, buildInfoNotGoExecutableMessage):
+		classified.category = errorNotGo
+	case errors.As(err, &elfErr), errors.As(err, &machoErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, gore.ErrNotEnoughBytesRead), errors.Is(err, gore.ErrSectionDoesNotExist), errors.Is(err, gore.ErrNoPCLNTab):
+		// A pclntab gore can't find may still be found by scanning the file, files that aren't Go have none either way.
+		classified.category = errorCorrupted
+	}
+	return classified
+}
+
+// Classify the error gore returned opening a file.
+// Gore parses PE files with debug/pe, so a PE file debug/pe can't parse is damaged whatever gore's message says.
+func classifyOpenError(err error, contentFilePath string) *classifiedError {
+	classified := classifyError(err)
+	data, readErr := os.ReadFile(contentFilePath)
+	if readErr != nil {
+		return classified
+	}
+	switch checkPEDamage(data) {
+	case peSymbolTableDamaged:
+		classified.category = errorCorrupted
+		classified.peSymbolTable = true
+	case peHeadersDamaged:
+		classified.category = errorCorrupted
+	}
+	return classified
+}
@@ -0,0 +1,173 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goretk/gore"
+)
+
+// Build an error from a panic the way gore calls report it.
+func goreTestPanic(message string) error {
+	_, err := callGore(analysisStagePackages, "test.bin", func() (int, error) { panic(message) })
+	return err
+}
+
+// Build a valid 64 bit ELF header with no sections or segments, an executable that isn't Go.
+func emptyElfHeader() []byte {
+	header := make([]byte, 64)
+	copy(header, "\x7fELF\x02\x01\x01")
+	binary.LittleEndian.PutUint16(header[16:], uint16(elf.ET_EXEC))
+	binary.LittleEndian.PutUint16(header[18:], uint16(elf.EM_X86_64))
+	binary.LittleEndian.PutUint32(header[20:], 1)
+	binary.LittleEndian.PutUint16(header[52:], 64)
+	return header
+}
+
+// The errors debug/buildinfo returns are unexported, so get them by reading files.
+func buildInfoTestError(t *testing.T, data []byte) error {
+	t.Helper()
+	_, err := buildinfo.Read(bytes.NewReader(data))
+	if err == nil {
+		t.Fatal("expected debug/buildinfo to fail")
+	}
+	return err
+}
+
+func TestClassifyError(t *testing.T) {
+	_, elfErr := elf.NewFile(bytes.NewReader([]byte("\x7fELF\x09junk")))
+	_, machoErr := macho.NewFile(bytes.NewReader([]byte("\xcf\xfa\xed\xfe\x07\x00\x00\x01junk")))
+
+	testCases := []struct {
+		description   string
+		err           error
+		category      errorCategory
+		peSymbolTable bool
+	}{
+		{"gore unsupported file", gore.ErrUnsupportedFile, errorNotExecutable, false},
+		{"build info of a text file", buildInfoTestError(t, []byte("package main")), errorNotExecutable, false},
+		{"gore no go version", fmt.Errorf("compiler version: %w", gore.ErrNoGoVersionFound), errorNotGo, false},
+		{"gore no pclntab", gore.ErrNoPCLNTab, errorCorrupted, false},
+		{"build info of a non Go ELF", buildInfoTestError(t, emptyElfHeader()), errorNotGo, false},
+		{"debug/elf format error", fmt.Errorf("error when parsing the ELF file: %w", elfErr), errorCorrupted, false},
+		{"debug/macho format error", fmt.Errorf("error when parsing the Mach-O file: %w", machoErr), errorCorrupted, false},
+		{"truncated read", fmt.Errorf("read header: %w", io.ErrUnexpectedEOF), errorCorrupted, false},
+		{"gore short read", gore.ErrNotEnoughBytesRead, errorCorrupted, false},
+		{"gore unsupported architecture", goreTestPanic(goreUnsupportedArchPanic), errorUnsupportedArch, false},
+		{"gore panic", goreTestPanic("runtime error: index out of range"), errorInternalBug, false},
+		{"unknown error", errors.New("something went wrong"), errorInternalBug, false},
+	}
+	for _, tc := range testCases {
+		if tc.err == nil {
+			t.Errorf("%s: expected an error to classify", tc.description)
+			continue
+		}
+		classified := classifyError(tc.err)
+		if classified.category != tc.category || classified.peSymbolTable != tc.peSymbolTable {
+			t.Errorf("%s: expected %s (symbol table %v) for %q, got %s (symbol table %v)",
+				tc.description, tc.category, tc.peSymbolTable, tc.err, classified.category, classified.peSymbolTable)
+		}
+		if !errors.Is(classified, tc.err) {
+			t.Errorf("%s: expected the classified error to wrap the original", tc.description)
+		}
+	}
+}
+
+func TestErrorCategoryOutcome(t *testing.T) {
+	expected := map[errorCategory]errorOutcome{
+		errorNotExecutable:   outcomeOptOut,
+		errorNotGo:           outcomeOptOut,
+		errorCorrupted:       outcomeFeature,
+		errorUnsupportedArch: outcomeFeature,
+		errorInternalBug:     outcomeError,
+	}
+	for category, outcome := range expected {
+		if category.outcome() != outcome {
+			t.Errorf("expected %s to map to outcome %d, got %d", category, outcome, category.outcome())
+		}
+	}
+}
+
+// Corrupt the COFF symbol table of a PE file the way damaged samples are.
+func corruptTestPESymbolTable(t *testing.T, binaryPath string, corrupt func(data []byte, symbolTable int, symbolCount int)) string {
+	return corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		peHeaderOffset := int(binary.LittleEndian.Uint32(data[0x3c:]))
+		symbolTable := int(binary.LittleEndian.Uint32(data[peHeaderOffset+12:]))
+		symbolCount := int(binary.LittleEndian.Uint32(data[peHeaderOffset+16:]))
+		if symbolTable == 0 || symbolCount == 0 {
+			t.Fatal("expected the test program to have a symbol table")
+		}
+		corrupt(data, symbolTable, symbolCount)
+		return data
+	})
+}
+
+// Debug/buildinfo's errors are recognised by their messages, which must match the toolchain's.
+func TestBuildInfoErrorMessages(t *testing.T) {
+	if category := classifyError(buildInfoTestError(t, []byte("package main"))).category; category != errorNotExecutable {
+		t.Errorf("expected a text file to be %s got %s", errorNotExecutable, category)
+	}
+	if category := classifyError(buildInfoTestError(t, emptyElfHeader())).category; category != errorNotGo {
+		t.Errorf("expected an ELF file without Go to be %s got %s", errorNotGo, category)
+	}
+	source, err := os.ReadFile(filepath.Join(runtime.GOROOT(), "src", "debug", "buildinfo", "buildinfo.go"))
+	if err != nil {
+		t.Skipf("debug/buildinfo source not found: %v", err)
+	}
+	for _, message := range []string{buildInfoUnrecognizedFormatMessage, buildInfoNotGoExecutableMessage} {
+		if !bytes.Contains(source, []byte(`errors.New("`+message+`")`)) {
+			t.Errorf("expected debug/buildinfo in %s to define the error %q", runtime.Version(), message)
+		}
+	}
+}
+
+func TestClassifyOpenErrorPE(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=windows", "GOARCH=amd64"})
+	peHeaderOffset := func(data []byte) int { return int(binary.LittleEndian.Uint32(data[0x3c:])) }
+	testCases := []struct {
+		description   string
+		corrupt       func(data []byte, symbolTable int, symbolCount int)
+		peSymbolTable bool
+	}{
+		{"string table length past the end", func(data []byte, symbolTable int, symbolCount int) {
+			binary.LittleEndian.PutUint32(data[peHeaderOffset(data)+12:], uint32(len(data)))
+		}, true},
+		{"string table past the end", func(data []byte, symbolTable int, symbolCount int) {
+			binary.LittleEndian.PutUint32(data[symbolTable+symbolCount*pe.COFFSymbolSize:], 0x7fffffff)
+		}, true},
+		{"aux symbols past the end", func(data []byte, symbolTable int, symbolCount int) {
+			data[symbolTable+(symbolCount-1)*pe.COFFSymbolSize+17] = 1
+		}, true},
+		{"optional header magic", func(data []byte, symbolTable int, symbolCount int) {
+			binary.LittleEndian.PutUint16(data[peHeaderOffset(data)+24:], 0)
+		}, false},
+	}
+	for _, tc := range testCases {
+		corruptedPath := corruptTestPESymbolTable(t, binaryPath, tc.corrupt)
+		_, err := gore.Open(corruptedPath)
+		if err == nil {
+			t.Errorf("%s: expected gore to fail to open the file", tc.description)
+			continue
+		}
+		if classified := classifyOpenError(err, corruptedPath); classified.category != errorCorrupted || classified.peSymbolTable != tc.peSymbolTable {
+			t.Errorf("%s: expected %s (symbol table %v) for %q, got %s (symbol table %v)",
+				tc.description, errorCorrupted, tc.peSymbolTable, err, classified.category, classified.peSymbolTable)
+		}
+	}
+
+	// Failures on a PE file debug/pe parses are classified by the error alone.
+	if classified := classifyOpenError(errors.New("something went wrong"), binaryPath); classified.category != errorInternalBug {
+		t.Errorf("expected an intact PE file to keep the error's category, got %s", classified.category)
+	}
+}
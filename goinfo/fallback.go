@@ -0,0 +1,179 @@
+package goinfo
+
+import (
+	"context"
+	debugBuildInfo "debug/buildinfo"
+	"debug/gosym"
+	"os"
+	"strings"
+)
+
+// Classifies package paths as user code, 3rd party modules or the standard library using the build info.
+type packageClassifier struct {
+	mainModule string
+	// Module paths of all dependencies.
+	dependencies []string
+	// Prefixes from the settings that override the classification.
+	prefixes *packagePrefixes
+}
+
+// Returns true if the package belongs to a 3rd party module.
+func (pc *packageClassifier) isVendor(packagePath string) bool {
+	if user, matched := pc.prefixes.classify(packagePath); matched {
+		return !user
+	}
+	packagePath = strings.TrimPrefix(packagePath, "vendor/")
+	for _, dependency := range pc.dependencies {
+		if packagePath == dependency || strings.HasPrefix(packagePath, dependency+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the package was written by the author of the binary.
+func (pc *packageClassifier) isUser(packagePath string) bool {
+	if user, matched := pc.prefixes.classify(packagePath); matched {
+		return user
+	}
+	if packagePath == "main" {
+		return true
+	}
+	if pc.mainModule != "" && (packagePath == pc.mainModule || strings.HasPrefix(packagePath, pc.mainModule+"/")) {
+		return true
+	}
+	return !isStandardPackagePath(packagePath) && !pc.isVendor(packagePath) && !strings.HasPrefix(packagePath, "vendor/")
+}
+
+// Analyse a Go binary without gore, for architectures gore can't disassemble.
+// Only architecture independent metadata is extracted: build info, build ID and everything in the pclntab.
+// The opt out message is returned in the summary if no Go metadata could be found at all.
+func (gi *Analyzer) analyseFallback(ctx context.Context, features *featureWriter, contentFilePath string, reason string, optOutMessage string) (*goFileSummary, error) {
+	fileData, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return nil, newError("Could not be read", "Failed to read the file for fallback analysis", err)
+	}
+	buildInfo, buildInfoErr := debugBuildInfo.ReadFile(contentFilePath)
+	if buildInfoErr != nil {
+		// Corrupted headers stop the build info being found through its section, so look for it directly.
+		if scannedBuildInfo := findMemoryBuildInfo(fileData, nil); scannedBuildInfo != nil {
+			buildInfo, buildInfoErr = scannedBuildInfo, nil
+		}
+	}
+	pclntabData, textStart, pclntabErr := locatePclntab(contentFilePath)
+	if buildInfoErr != nil && pclntabErr != nil {
+		return &goFileSummary{optOutMessage: optOutMessage}, nil
+	}
+
+	features.report.setFallback(reason)
+	features.addFeature("go_analysis_fallback", reason)
+
+	summary := &goFileSummary{}
+	classifier := &packageClassifier{prefixes: gi.settings.userCodePrefixes()}
+	buildSettings := map[string]string{}
+	if buildInfoErr == nil {
+		summary.goVersion = buildInfo.GoVersion
+		features.report.setBuildInfo(buildInfo)
+		classifier.mainModule = buildInfo.Main.Path
+		for _, dep := range buildInfo.Deps {
+			summary.modules = append(summary.modules, dep.Path+"@"+dep.Version)
+			classifier.dependencies = append(classifier.dependencies, dep.Path)
+		}
+		for _, s := range buildInfo.Settings {
+			buildSettings[s.Key] = s.Value
+			features.addFeatureWithExtra("go_compiler_flag", s.Value, &featureOptions{
+				Label: s.Key,
+			})
+		}
+		features.addFeature("go_compiler_version", buildInfo.GoVersion)
+	}
+
+	buildID := findGoBuildID(fileData)
+	if buildID != "" {
+		features.report.BuildID = buildID
+		features.addFeature("go_build_id", buildID)
+	}
+
+	addBuildModeFeatures(features, detectBuildMode(contentFilePath, buildSettings))
+
+	if pclntabErr != nil {
+		addAnalysisError(features, analysisStagePclntab, pclntabErr)
+		addCgoFeatures(features, detectCgo(contentFilePath, buildSettings, nil))
+		return summary, nil
+	}
+	pclntab, err := parsePclntab(pclntabData, textStart)
+	if err != nil {
+		addAnalysisError(features, analysisStagePclntab, err)
+		addCgoFeatures(features, detectCgo(contentFilePath, buildSettings, nil))
+		return summary, nil
+	}
+	addCgoFeatures(features, detectCgo(contentFilePath, buildSettings, pclntab))
+	if summary.goVersion == "" {
+		// Without build info the pclntab layout still narrows down the Go version.
+		summary.goVersion = pclntabVersion(pclntabData)
+		features.report.GoVersion = summary.goVersion
+	}
+
+	if !gi.settings.stageEnabled(analysisStagePackages) {
+		return summary, nil
+	}
+	stageCtx, cancelStage := gi.startStage(ctx, analysisStagePackages)
+	defer cancelStage()
+	addPclntabPackageFeatures(stageCtx, features, classifier, pclntab)
+	return summary, nil
+}
+
+// Add the user packages, their functions and methods and the vendor packages found in the pclntab.
+// Stops early and records the stage as truncated when the context is done.
+func addPclntabPackageFeatures(ctx context.Context, features *featureWriter, classifier *packageClassifier, pclntab *gosym.Table) {
+	generics := &genericFunctions{}
+	closures := &closureTree{}
+	for _, pkg := range groupPclntabPackages(pclntabFunctions(pclntab)) {
+		if ctx.Err() != nil {
+			addTruncatedStage(features, analysisStagePackages, ctx.Err())
+			return
+		}
+		if classifier.isVendor(pkg.name) {
+			features.report.addPackage(pkg.name, packageKindLibrary, pkg.directory)
+			features.addFeature("go_vendor_package", pkg.name)
+			continue
+		}
+		if !classifier.isUser(pkg.name) {
+			continue
+		}
+		features.report.addPackage(pkg.name, packageKindUser, pkg.directory).addPclntabFunctions(pkg)
+		features.addFeature("go_package", pkg.name)
+		if pkg.directory != "" && pkg.directory != "." {
+			features.addFeature("go_file", pkg.directory)
+		}
+		for _, pkgFunc := range pkg.functions {
+			generics.add(pkgFunc.parsed)
+			closures.add(pkgFunc.parsed, pkgFunc.entry, pkgFunc.end)
+			features.addFeatureWithExtra(
+				"go_package_function",
+				pkgFunc.name,
+				&featureOptions{
+					Label:  pkg.name,
+					Offset: pkgFunc.entry,
+					Size:   pkgFunc.end - pkgFunc.entry,
+				},
+			)
+		}
+		for _, pkgMethod := range pkg.methods {
+			generics.add(pkgMethod.parsed)
+			closures.add(pkgMethod.parsed, pkgMethod.entry, pkgMethod.end)
+			features.addFeatureWithExtra(
+				"go_package_method",
+				pkgMethod.name,
+				&featureOptions{
+					Label:  pkg.name,
+					Offset: pkgMethod.entry,
+					Size:   pkgMethod.end - pkgMethod.entry,
+				},
+			)
+			addMethodReceiverFeature(features, pkgMethod.parsed, pkgMethod.entry, pkgMethod.end)
+		}
+	}
+	addGenericFunctionFeatures(features, generics)
+	addClosureFeatures(features, closures)
+}
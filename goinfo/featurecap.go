@@ -0,0 +1,96 @@
+package goinfo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Maximum number of values kept for a capped feature when the settings don't give one.
+const defaultFeatureCap = 1000
+
+// Features that can have thousands of values in a large binary. Their values are held back until the
+// file is finished so only the most distinctive are kept, and their true total is reported in a count feature.
+var cappedFeatures = []string{"go_package_function", "go_package_method", "go_method_receiver", "go_closure", "go_type", "go_type_method"}
+
+// Closures are named after the enclosing function with a funcN suffix, e.g. main.main.func1.2.
+var closureNamePattern = regexp.MustCompile(`(^|\.)func\d+(\.\d+)*$`)
+
+// A feature value held back by the feature writer.
+type heldFeature struct {
+	value   string
+	options featureOptions
+}
+
+// Name of the feature holding the total number of values of a capped feature.
+func featureCountName(name string) string {
+	return name + "_count"
+}
+
+func isCappedFeature(name string) bool {
+	for _, capped := range cappedFeatures {
+		if capped == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank how distinctive a feature value is, lower ranks are kept first when a feature is capped.
+// Code written by the author beats generic instantiations, compiler generated wrappers and closures.
+func featureValueRank(name string, value string) int {
+	if name == "go_type" {
+		switch {
+		case strings.ContainsAny(value, "[{(") || strings.HasPrefix(value, "func"):
+			// Anonymous and instantiated types.
+			return 2
+		case strings.HasPrefix(value, "*"):
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case closureNamePattern.MatchString(value):
+		return 3
+	case strings.HasSuffix(value, "-fm") || strings.Contains(value, "deferwrap") || strings.Contains(value, "gowrap") ||
+		strings.Contains(value, "go.shape.") || value == "init" || strings.HasPrefix(value, "init."):
+		return 2
+	case strings.Contains(value, "["):
+		return 1
+	}
+	return 0
+}
+
+// Order held values from most to least distinctive, keeping the order they were found in for equal ranks.
+func rankHeldFeatures(name string, held []heldFeature) []heldFeature {
+	ranked := make([]heldFeature, len(held))
+	copy(ranked, held)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return featureValueRank(name, ranked[i].value) < featureValueRank(name, ranked[j].value)
+	})
+	return ranked
+}
+
+// Write the held values of every capped feature, keeping only the most distinctive up to each feature's cap.
+// A count feature with the true total is always written so analysts can tell when values were dropped.
+func (fw *featureWriter) flush() {
+	for _, name := range cappedFeatures {
+		if !fw.settings.featureEnabled(name) {
+			continue
+		}
+		held := fw.held[name]
+		limit := fw.settings.featureCap(name)
+		countOptions := &featureOptions{}
+		if limit > 0 && len(held) > limit {
+			held = rankHeldFeatures(name, held)[:limit]
+			countOptions.Label = fmt.Sprintf("capped at %d", limit)
+		}
+		for _, feature := range held {
+			fw.writeFeature(name, feature.value, &feature.options)
+		}
+		fw.writeFeature(featureCountName(name), strconv.Itoa(len(fw.held[name])), countOptions)
+		delete(fw.held, name)
+	}
+}
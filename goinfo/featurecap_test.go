@@ -0,0 +1,65 @@
+package goinfo
+
+import (
+	"testing"
+)
+
+func TestFeatureValueRank(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		rank  int
+	}{
+		{"go_package_function", "main", 0},
+		{"go_package_function", "Map[go.shape.int]", 2},
+		{"go_package_function", "Map[...]", 1},
+		{"go_package_function", "main.func1", 3},
+		{"go_package_function", "func2.3", 3},
+		{"go_package_function", "run.deferwrap1", 2},
+		{"go_package_function", "init.0", 2},
+		{"go_package_method", "(*Config).Send-fm", 2},
+		{"go_type_method", "Send", 0},
+		{"go_type", "main.Config", 0},
+		{"go_type", "*main.Config", 1},
+		{"go_type", "main.List[int]", 2},
+		{"go_type", "struct { F uintptr }", 2},
+	}
+	for _, tc := range testCases {
+		if rank := featureValueRank(tc.name, tc.value); rank != tc.rank {
+			t.Errorf("expected %s %q to rank %d, got %d", tc.name, tc.value, tc.rank, rank)
+		}
+	}
+}
+
+func TestRankHeldFeatures(t *testing.T) {
+	held := []heldFeature{{value: "main.func1"}, {value: "beacon"}, {value: "init"}, {value: "decrypt"}}
+	ranked := rankHeldFeatures("go_package_function", held)
+	expected := []string{"beacon", "decrypt", "init", "main.func1"}
+	for i, feature := range ranked {
+		if feature.value != expected[i] {
+			t.Errorf("expected %v, got %v", expected, ranked)
+			break
+		}
+	}
+	if held[0].value != "main.func1" {
+		t.Errorf("expected the held values to be left in order")
+	}
+}
+
+func TestFeatureCapSettings(t *testing.T) {
+	settings, err := LoadSettings([]string{"PLUGIN_GOINFO_FEATURE_CAP=200", "PLUGIN_GOINFO_FEATURE_CAP_GO_TYPE=0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.featureCap("go_package_function") != 200 || settings.featureCap("go_type") != 0 {
+		t.Errorf("expected caps of 200 and 0, got %d and %d", settings.featureCap("go_package_function"), settings.featureCap("go_type"))
+	}
+	var defaults *Settings
+	if defaults.featureCap("go_type") != defaultFeatureCap {
+		t.Errorf("expected the default cap without settings")
+	}
+	_, err = LoadSettings([]string{"PLUGIN_GOINFO_FEATURE_CAP=-1"})
+	if err == nil {
+		t.Errorf("expected an error for a negative cap")
+	}
+}
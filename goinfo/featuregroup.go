@@ -0,0 +1,85 @@
+package goinfo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Family of features that can be turned on or off together.
+type featureGroup string
+
+const (
+	groupPackages    featureGroup = "packages"
+	groupFunctions   featureGroup = "functions"
+	groupMethods     featureGroup = "methods"
+	groupTypes       featureGroup = "types"
+	groupTypeMethods featureGroup = "type_methods"
+	groupFiles       featureGroup = "files"
+	groupBuildFlags  featureGroup = "build_flags"
+)
+
+var allFeatureGroups = []featureGroup{groupPackages, groupFunctions, groupMethods, groupTypes, groupTypeMethods, groupFiles, groupBuildFlags}
+
+// Features belonging to each group, features that aren't listed are always written.
+var featureGroupMembers = map[string]featureGroup{
+	"go_package":                     groupPackages,
+	"go_vendor_package":              groupPackages,
+	"go_package_function":            groupFunctions,
+	"go_package_function_count":      groupFunctions,
+	"go_closure":                     groupFunctions,
+	"go_closure_count":               groupFunctions,
+	"go_function_closure_count":      groupFunctions,
+	"go_generic_function":            groupFunctions,
+	"go_generic_instantiation_count": groupFunctions,
+	"go_cgo_function":                groupFunctions,
+	"go_package_method":              groupMethods,
+	"go_package_method_count":        groupMethods,
+	"go_method_receiver":             groupMethods,
+	"go_method_receiver_count":       groupMethods,
+	"go_type":                        groupTypes,
+	"go_type_count":                  groupTypes,
+	"go_type_method":                 groupTypeMethods,
+	"go_type_method_count":           groupTypeMethods,
+	"go_file":                        groupFiles,
+	"go_cgo_file":                    groupFiles,
+	"go_compiler_flag":               groupBuildFlags,
+}
+
+// Feature groups needing each stage of the analysis, a stage is only started when one of its groups is on.
+// Stages that aren't listed always run.
+var analysisStageGroups = map[string][]featureGroup{
+	// The pclntab is only read for the C functions and files of cgo binaries, and the full symbols of
+	// the functions and methods gore splits wrongly.
+	analysisStagePclntab: {groupFunctions, groupMethods, groupFiles},
+	// User types are told apart by the user packages, and the settings can move vendor packages into them.
+	analysisStagePackages: {groupPackages, groupFunctions, groupMethods, groupFiles, groupTypes, groupTypeMethods},
+	analysisStageVendors:  {groupPackages, groupFunctions, groupMethods, groupFiles, groupTypes, groupTypeMethods},
+	analysisStageTypes:    {groupTypes, groupTypeMethods},
+}
+
+// Named sets of feature groups for deployments wanting different depths of analysis.
+// The build info features aren't in any group so every profile has them.
+var analysisProfiles = map[string][]featureGroup{
+	// Build info and package names only. The pclntab and type stages are never started and the
+	// packages aren't walked for their functions, methods and files.
+	"fast": {groupBuildFlags, groupPackages},
+	"full": allFeatureGroups,
+}
+
+const defaultAnalysisProfile = "full"
+
+// Feature groups turned on by a profile.
+func profileFeatureGroups(profile string) (map[featureGroup]bool, error) {
+	groups, ok := analysisProfiles[strings.ToLower(profile)]
+	if !ok {
+		return nil, fmt.Errorf("unknown analysis profile %q", profile)
+	}
+	enabled := map[featureGroup]bool{}
+	for _, group := range allFeatureGroups {
+		enabled[group] = false
+	}
+	for _, group := range groups {
+		enabled[group] = true
+	}
+	return enabled, nil
+}
@@ -0,0 +1,99 @@
+package goinfo
+
+import (
+	"testing"
+)
+
+func TestAnalysisProfiles(t *testing.T) {
+	testCases := []struct {
+		environ  []string
+		enabled  []string
+		disabled []string
+	}{
+		{nil, []string{"go_package", "go_package_function", "go_type", "go_type_method_count", "go_file", "go_compiler_flag"}, nil},
+		{
+			[]string{"PLUGIN_GOINFO_PROFILE=fast"},
+			[]string{"go_package", "go_vendor_package", "go_build_id", "go_compiler_flag"},
+			[]string{"go_package_function", "go_package_method", "go_type", "go_type_method", "go_type_count", "go_file", "go_cgo_function", "go_cgo_file"},
+		},
+		{
+			// A group set on its own wins over the profile even when it comes first.
+			[]string{"PLUGIN_GOINFO_GROUP_FUNCTIONS=true", "PLUGIN_GOINFO_PROFILE=fast"},
+			[]string{"go_package_function", "go_package_function_count"},
+			[]string{"go_type", "go_package_method"},
+		},
+		{
+			[]string{"PLUGIN_GOINFO_PROFILE=full", "PLUGIN_GOINFO_GROUP_TYPES=false", "PLUGIN_GOINFO_GROUP_BUILD_FLAGS=0"},
+			[]string{"go_type_method", "go_package_method"},
+			[]string{"go_type", "go_compiler_flag"},
+		},
+	}
+	for _, tc := range testCases {
+		settings, err := LoadSettings(tc.environ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range tc.enabled {
+			if !settings.featureEnabled(name) {
+				t.Errorf("expected %s to be written with %v", name, tc.environ)
+			}
+		}
+		for _, name := range tc.disabled {
+			if settings.featureEnabled(name) {
+				t.Errorf("expected %s to be dropped with %v", name, tc.environ)
+			}
+		}
+	}
+
+	var defaults *Settings
+	if !defaults.groupEnabled(groupTypes) {
+		t.Errorf("expected every group to be on without settings")
+	}
+	for _, environ := range [][]string{{"PLUGIN_GOINFO_PROFILE=deep"}, {"PLUGIN_GOINFO_GROUP_SYMBOLS=true"}, {"PLUGIN_GOINFO_GROUP_TYPES=maybe"}} {
+		if _, err := LoadSettings(environ); err == nil {
+			t.Errorf("expected an error for %v", environ)
+		}
+	}
+}
+
+func TestAnalysisProfileStages(t *testing.T) {
+	testCases := []struct {
+		environ []string
+		started []string
+		skipped []string
+	}{
+		{nil, []string{analysisStageCompilerVersion, analysisStagePclntab, analysisStagePackages, analysisStageVendors, analysisStageTypes}, nil},
+		{
+			[]string{"PLUGIN_GOINFO_PROFILE=fast"},
+			[]string{analysisStageCompilerVersion, analysisStagePackages, analysisStageVendors},
+			[]string{analysisStagePclntab, analysisStageTypes},
+		},
+		{
+			[]string{"PLUGIN_GOINFO_PROFILE=fast", "PLUGIN_GOINFO_GROUP_PACKAGES=false"},
+			[]string{analysisStageCompilerVersion},
+			[]string{analysisStagePclntab, analysisStagePackages, analysisStageVendors, analysisStageTypes},
+		},
+		{
+			// The user types are told apart by the user packages.
+			[]string{"PLUGIN_GOINFO_PROFILE=fast", "PLUGIN_GOINFO_GROUP_PACKAGES=false", "PLUGIN_GOINFO_GROUP_TYPES=true"},
+			[]string{analysisStagePackages, analysisStageVendors, analysisStageTypes},
+			[]string{analysisStagePclntab},
+		},
+	}
+	for _, tc := range testCases {
+		settings, err := LoadSettings(tc.environ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, stage := range tc.started {
+			if !settings.stageEnabled(stage) {
+				t.Errorf("expected the %s stage to run with %v", stage, tc.environ)
+			}
+		}
+		for _, stage := range tc.skipped {
+			if settings.stageEnabled(stage) {
+				t.Errorf("expected the %s stage to be skipped with %v", stage, tc.environ)
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package goinfo
+
+import (
+	"bytes"
+	"context"
+	"debug/macho"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/goretk/gore"
+)
+
+// Builds of the corpus program used as the seed corpus of every fuzz target.
+// Most are stripped to keep the inputs small, the symbol tables are covered by the unstripped builds.
+var fuzzSeedTargets = []struct {
+	goos   string
+	goarch string
+	args   []string
+}{
+	{"linux", "amd64", nil},
+	{"linux", "386", []string{"-ldflags=-s -w"}},
+	{"linux", "arm64", []string{"-buildmode=pie", "-ldflags=-s -w"}},
+	{"windows", "amd64", nil},
+	{"windows", "386", []string{"-ldflags=-s -w"}},
+	{"darwin", "amd64", []string{"-ldflags=-s -w"}},
+	{"darwin", "arm64", []string{"-ldflags=-s -w"}},
+}
+
+// A compiled seed, kept so every fuzz target in the process only builds the seeds once.
+type fuzzSeed struct {
+	goos   string
+	goarch string
+	data   []byte
+}
+
+// Compile the seed targets, leaving out the ones the local toolchain can't build.
+var fuzzSeeds = sync.OnceValues(func() ([]fuzzSeed, []error) {
+	seeds := []fuzzSeed{}
+	errs := []error{}
+	for _, target := range fuzzSeedTargets {
+		dir, err := os.MkdirTemp("", "goinfo-fuzz-seed")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		binaryPath, err := compileTestProgram(dir, corpusSource, []string{"GOOS=" + target.goos, "GOARCH=" + target.goarch}, target.args...)
+		if err == nil {
+			var data []byte
+			data, err = os.ReadFile(binaryPath)
+			seeds = append(seeds, fuzzSeed{goos: target.goos, goarch: target.goarch, data: data})
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		os.RemoveAll(dir)
+	}
+	return seeds, errs
+})
+
+// Add the seed binaries to a fuzz target, along with a universal Mach-O of the darwin builds.
+func addFuzzSeeds(f *testing.F) []fuzzSeed {
+	f.Helper()
+	seeds, errs := fuzzSeeds()
+	for _, err := range errs {
+		f.Logf("seed not built with the local toolchain: %v", err)
+	}
+	universalCpus := []macho.Cpu{}
+	universalData := [][]byte{}
+	for _, seed := range seeds {
+		f.Add(seed.data)
+		if seed.goos == "darwin" {
+			universalCpus = append(universalCpus, map[string]macho.Cpu{"amd64": macho.CpuAmd64, "arm64": macho.CpuArm64}[seed.goarch])
+			universalData = append(universalData, seed.data)
+		}
+	}
+	if len(universalData) > 0 {
+		f.Add(universalMachoData(universalCpus, universalData))
+	}
+	return seeds
+}
+
+// Write a fuzz input to a file for the stages that read from a path.
+func writeFuzzInput(t *testing.T, data []byte) string {
+	t.Helper()
+	inputPath := filepath.Join(t.TempDir(), "input.bin")
+	err := os.WriteFile(inputPath, data, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inputPath
+}
+
+// The whole analysis, from unpacking and opening the file with gore through every extraction stage.
+// Gore panics are recovered into errors, so only panics outside gore and in goroutines gore starts fail.
+func FuzzAnalyze(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		report, err := NewAnalyzer(nil).Analyze(context.Background(), writeFuzzInput(t, data))
+		if err == nil && report == nil {
+			t.Fatal("expected a report when the analysis doesn't fail")
+		}
+	})
+}
+
+// Open the input with gore and run one stage on it the way the analysis does, recovering gore panics.
+// Panics in goroutines gore starts and crashes that can't be recovered, such as running out of memory, still fail.
+func fuzzGoreStage[T any](f *testing.F, stage string, call func(goFile *gore.GoFile) (T, error)) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		inputPath := writeFuzzInput(t, data)
+		goFile, err := callGore(analysisStageOpen, inputPath, func() (*gore.GoFile, error) { return gore.Open(inputPath) })
+		if err != nil {
+			return
+		}
+		defer goFile.Close()
+		// The analysis doesn't run any gore stage when gore would run out of memory building the pclntab.
+		if err := checkGorePclntab(goFile, inputPath); err != nil {
+			return
+		}
+		_, _ = callGore(stage, inputPath, func() (T, error) { return call(goFile) })
+	})
+}
+
+func FuzzGoreOpen(f *testing.F) {
+	fuzzGoreStage(f, analysisStageOpen, func(goFile *gore.GoFile) (string, error) { return goFile.BuildID, nil })
+}
+
+func FuzzGoreCompilerVersion(f *testing.F) {
+	fuzzGoreStage(f, analysisStageCompilerVersion, (*gore.GoFile).GetCompilerVersion)
+}
+
+func FuzzGorePclntab(f *testing.F) {
+	fuzzGoreStage(f, analysisStagePclntab, (*gore.GoFile).PCLNTab)
+}
+
+func FuzzGorePackages(f *testing.F) {
+	fuzzGoreStage(f, analysisStagePackages, func(goFile *gore.GoFile) ([]*gore.SourceFile, error) {
+		packages, err := goFile.GetPackages()
+		sourceFiles := []*gore.SourceFile{}
+		for _, pkg := range packages {
+			sourceFiles = append(sourceFiles, goFile.GetSourceFiles(pkg)...)
+		}
+		return sourceFiles, err
+	})
+}
+
+func FuzzGoreVendors(f *testing.F) {
+	fuzzGoreStage(f, analysisStageVendors, func(goFile *gore.GoFile) ([]*gore.Package, error) {
+		packages, err := goFile.GetVendors()
+		for _, getPackages := range []func() ([]*gore.Package, error){goFile.GetSTDLib, goFile.GetUnknown} {
+			others, otherErr := getPackages()
+			packages = append(packages, others...)
+			err = errors.Join(err, otherErr)
+		}
+		return packages, err
+	})
+}
+
+func FuzzGoreTypes(f *testing.F) {
+	fuzzGoreStage(f, analysisStageTypes, (*gore.GoFile).GetTypes)
+}
+
+func FuzzValidateStructure(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = validateStructure(writeFuzzInput(t, data))
+	})
+}
+
+func FuzzDetectBuildMode(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		detectBuildMode(writeFuzzInput(t, data), map[string]string{})
+	})
+}
+
+func FuzzPclntab(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		inputPath := writeFuzzInput(t, data)
+		pclntabData, textStart, err := locatePclntab(inputPath)
+		if err != nil {
+			return
+		}
+		table, err := parsePclntab(pclntabData, textStart)
+		if err != nil {
+			return
+		}
+		pclntabFunctions(table)
+		detectCgo(inputPath, map[string]string{}, table)
+	})
+}
+
+// Memory dumps have no header to check, so every byte of the input is scanned for Go structures.
+func FuzzMemoryScan(f *testing.F) {
+	for _, seed := range addFuzzSeeds(f) {
+		// The build info blob on its own, which the fallback analysis scans for without a module.
+		if index := bytes.Index(seed.data, goBuildInfoMarker); index >= 0 {
+			f.Add(seed.data[index:min(index+256, len(seed.data))])
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		findMemoryBuildInfo(data, nil)
+		module := findMemoryModule(data)
+		if module == nil {
+			return
+		}
+		scanMemoryTypes(data, module)
+		findMemoryBuildInfo(data, module)
+	})
+}
+
+func FuzzUpxUnpack(f *testing.F) {
+	for _, seed := range addFuzzSeeds(f) {
+		switch {
+		case seed.goos == "linux" && seed.goarch == "amd64":
+			f.Add(upxTestPackElf(f, seed.data))
+		case seed.goos == "windows" && seed.goarch == "amd64":
+			f.Add(upxTestPackPE(f, seed.data))
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = upxUnpack(data)
+	})
+}
+
+func FuzzExtractMachoSlices(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		slices, err := extractMachoSlices(writeFuzzInput(t, data))
+		if err == nil {
+			removeMachoSlices(slices)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package goinfo
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Instantiations of generic functions and methods grouped by their generic base function.
+// The compiler names each instantiation after its type arguments, e.g. 'pkg.Map[go.shape.int,go.shape.string]',
+// which would otherwise show up as many near identical functions that differ between builds.
+type genericFunctions struct {
+	// Type argument lists of each instantiation of a base function, in the order they were found.
+	// Go 1.21 and later write every instantiation as '[...]' so the lists aren't unique.
+	instantiations map[string][]string
+}
+
+// Name of a generic function without its type arguments, e.g. 'example.com/list.(*List).Push'.
+func genericBaseName(symbol goSymbol) string {
+	return qualifiedFunctionName(symbol, symbol.functionName())
+}
+
+// Record a function if it is an instantiation of a generic function.
+func (g *genericFunctions) add(symbol goSymbol) {
+	if symbol.typeArguments == "" {
+		return
+	}
+	if g.instantiations == nil {
+		g.instantiations = map[string][]string{}
+	}
+	base := genericBaseName(symbol)
+	g.instantiations[base] = append(g.instantiations[base], symbol.typeArguments)
+}
+
+// Base functions sorted by name.
+func (g *genericFunctions) baseNames() []string {
+	names := make([]string, 0, len(g.instantiations))
+	for name := range g.instantiations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List the distinct type arguments of the instantiations of a base function, e.g. '[go.shape.int] [go.shape.string]'.
+func (g *genericFunctions) instantiationLabel(base string) string {
+	lists := []string{}
+	for _, typeArguments := range g.instantiations[base] {
+		lists = append(lists, "["+typeArguments+"]")
+	}
+	sort.Strings(lists)
+	return strings.Join(slices.Compact(lists), " ")
+}
+
+// Add a feature for each generic base function, labelled with its instantiations, and the number of them.
+func addGenericFunctionFeatures(features *featureWriter, generics *genericFunctions) {
+	for _, base := range generics.baseNames() {
+		features.report.addGeneric(base, generics.instantiations[base])
+		features.addFeatureWithExtra("go_generic_function", base, &featureOptions{
+			Label: generics.instantiationLabel(base),
+		})
+		features.addFeatureWithExtra("go_generic_instantiation_count", strconv.Itoa(len(generics.instantiations[base])), &featureOptions{
+			Label: base,
+		})
+	}
+}
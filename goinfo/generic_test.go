@@ -0,0 +1,39 @@
+package goinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenericFunctions(t *testing.T) {
+	generics := &genericFunctions{}
+	for _, symbol := range []string{
+		"main.Map[go.shape.int,go.shape.string]",
+		"main.Map[go.shape.string,go.shape.int]",
+		"main.Map[go.shape.int,go.shape.string]",
+		"main.Filter[...]",
+		"main.Filter[...]",
+		"main.Map[go.shape.int,go.shape.string].func1",
+		"example.com/list.(*List[go.shape.int]).Push",
+		"example.com/list.List[go.shape.string].Len",
+		"main.main",
+		"main.(*Config).Send",
+	} {
+		generics.add(parseGoSymbol(symbol))
+	}
+
+	expectedNames := []string{"example.com/list.(*List).Push", "example.com/list.List.Len", "main.Filter", "main.Map", "main.Map.func1"}
+	if names := generics.baseNames(); !slices.Equal(names, expectedNames) {
+		t.Fatalf("expected base functions %v got %v", expectedNames, names)
+	}
+	if count := len(generics.instantiations["main.Map"]); count != 3 {
+		t.Errorf("expected 3 instantiations of main.Map got %d", count)
+	}
+	if label := generics.instantiationLabel("main.Filter"); label != "[...]" {
+		t.Errorf("expected label [...] got %q", label)
+	}
+	expectedLabel := "[go.shape.int,go.shape.string] [go.shape.string,go.shape.int]"
+	if label := generics.instantiationLabel("main.Map"); label != expectedLabel {
+		t.Errorf("expected label %q got %q", expectedLabel, label)
+	}
+}
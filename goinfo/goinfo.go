@@ -0,0 +1,581 @@
+// Package goinfo extracts the metadata of compiled Go binaries, such as the compiler version, build
+// info, packages, functions and types, using gore and falling back to its own parsers when gore can't
+// open a file. It is the analysis behind the Azul GoInfo plugin, without depending on the plugin runtime.
+package goinfo
+
+import (
+	"context"
+	debugBuildInfo "debug/buildinfo"
+	"debug/gosym"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/goretk/gore"
+)
+
+// Result of analysing a file. Universal Mach-O files have a binary report per architecture slice,
+// and the features of each slice have its architecture as a label prefix.
+type Report struct {
+	// Set when the file isn't a Go binary, with the reason it couldn't be analysed. Anything else in the
+	// report was found before the analysis gave up and shouldn't be published for an opted out file.
+	OptOut   string
+	Features []Feature
+	// Structured report of each Go binary analysed.
+	Binaries []*BinaryReport
+	// Candidate YARA rules built from the most distinctive artefacts of each binary.
+	YaraRules []string
+	// Executable unpacked from a UPX packed file, which is analysed in place of the file.
+	Unpacked []byte
+}
+
+// A single feature found in a binary, the label, offset and size are optional.
+type Feature struct {
+	Name   string
+	Value  string
+	Label  string
+	Offset uint64
+	Size   uint64
+}
+
+// Error that stops the analysis of a file, with a short title and a message for analysts.
+type Error struct {
+	Title   string
+	Message string
+	Err     error
+}
+
+func newError(title string, message string, err error) *Error {
+	return &Error{Title: title, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Analyses Go binaries with the same settings. It holds no per file state so one analyser can
+// analyse many files in parallel.
+type Analyzer struct {
+	// Read only settings shared by every analysis, the defaults are used when nil.
+	settings *Settings
+}
+
+// Create an analyser, nil settings use the defaults.
+func NewAnalyzer(settings *Settings) *Analyzer {
+	return &Analyzer{settings: settings}
+}
+
+// Analyse a file, unpacking it or splitting it into architecture slices first when needed.
+// An error is only returned when the file can't be analysed at all, failures of single extraction
+// stages are reported in the go_analysis_error feature instead.
+func (gi *Analyzer) Analyze(ctx context.Context, path string) (*Report, error) {
+	result := &Report{}
+	// UPX hides all of the Go metadata, so analyse the unpacked executable instead.
+	unpackedPath, err := gi.unpackUpx(&featureWriter{result: result, settings: gi.settings}, path)
+	if err != nil {
+		return nil, err
+	}
+	if unpackedPath != "" {
+		defer os.Remove(unpackedPath)
+		path = unpackedPath
+	}
+
+	// Universal Mach-O files hold a separate binary per architecture, so analyse each one.
+	slices, err := extractMachoSlices(path)
+	if err != nil {
+		return nil, newError("Mach-O slice extraction failed", "Failed to extract the architecture slices from a universal Mach-O file", err)
+	}
+	if len(slices) > 0 {
+		defer removeMachoSlices(slices)
+		result.OptOut, err = gi.analyseMachoSlices(ctx, result, slices)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	summary, err := gi.analyseFile(ctx, &featureWriter{result: result, settings: gi.settings}, path)
+	if err != nil {
+		return nil, err
+	}
+	result.OptOut = summary.optOutMessage
+	return result, nil
+}
+
+// Summary of a single analysed file, used to compare the slices of a universal Mach-O.
+type goFileSummary struct {
+	// Set when the file can't be analysed and the analysis should opt out with this message.
+	optOutMessage string
+	goVersion     string
+	// Module dependencies in the form path@version.
+	modules []string
+}
+
+// Optional parts of a feature.
+type featureOptions struct {
+	Label  string
+	Offset uint64
+	Size   uint64
+}
+
+// Adds features to the report, optionally prefixing every label so features from different
+// binaries in the same file can be told apart.
+// Values of the capped features are held back until flush is called, and features in groups
+// turned off by the settings are dropped.
+type featureWriter struct {
+	result      *Report
+	labelPrefix string
+	// Caps on the number of feature values, the defaults are used when nil.
+	settings *Settings
+	held     map[string][]heldFeature
+	// Structured report of the binary, added to the result when the analysis finishes.
+	report *BinaryReport
+}
+
+func (fw *featureWriter) addFeature(name string, value string) {
+	fw.addFeatureWithExtra(name, value, &featureOptions{})
+}
+
+func (fw *featureWriter) addFeatureWithExtra(name string, value string, options *featureOptions) {
+	if !fw.settings.featureEnabled(name) {
+		return
+	}
+	if isCappedFeature(name) {
+		if fw.held == nil {
+			fw.held = map[string][]heldFeature{}
+		}
+		fw.held[name] = append(fw.held[name], heldFeature{value: value, options: *options})
+		return
+	}
+	fw.writeFeature(name, value, options)
+}
+
+func (fw *featureWriter) writeFeature(name string, value string, options *featureOptions) {
+	label := options.Label
+	if fw.labelPrefix != "" {
+		if label == "" {
+			label = fw.labelPrefix
+		} else {
+			label = fw.labelPrefix + ":" + label
+		}
+	}
+	fw.result.Features = append(fw.result.Features, Feature{Name: name, Value: value, Label: label, Offset: options.Offset, Size: options.Size})
+}
+
+// A panic raised inside gore, returned as an error so a single bad file can't take down the program.
+type gorePanicError struct {
+	message string
+}
+
+func (e *gorePanicError) Error() string {
+	return fmt.Sprintf("gore panicked: %s", e.message)
+}
+
+// Run a gore call, converting any panic into a gorePanicError.
+// Every call recovers its own panic so concurrent analyses never see each other's errors.
+// The stage and file are only used to log the panic.
+func callGore[T any](stage string, contentFilePath string, call func() (T, error)) (result T, err error) {
+	defer func() {
+		goreRecover := recover()
+		if goreRecover == nil {
+			return
+		}
+		var zero T
+		result = zero
+		var message string
+		switch gorePanicMessage := goreRecover.(type) {
+		case string:
+			message = gorePanicMessage
+		case error:
+			message = gorePanicMessage.Error()
+		default:
+			message = fmt.Sprintf("%v", gorePanicMessage)
+		}
+		log.Printf("Gore panicked in the %s stage of %s: %s", stage, contentFilePath, message)
+		err = &gorePanicError{message: message}
+	}()
+	return call()
+}
+
+// Stages of the analysis. Every stage but opening the file can fail on its own without failing the analysis.
+const (
+	analysisStageOpen            = "open"
+	analysisStagePclntabCheck    = "pclntab_check"
+	analysisStageCompilerVersion = "compiler_version"
+	analysisStageBuildInfo       = "build_info"
+	analysisStagePclntab         = "pclntab"
+	analysisStagePackages        = "packages"
+	analysisStageVendors         = "vendors"
+	analysisStageTypes           = "types"
+)
+
+// Record that an extraction stage failed, the analysis carries on with the other stages.
+func addAnalysisError(features *featureWriter, stage string, err error) {
+	features.report.addWarning("analysis_error", stage, err.Error())
+	features.addFeatureWithExtra("go_analysis_error", err.Error(), &featureOptions{Label: stage})
+}
+
+// Convert a gore panic into the error that stops the analysis.
+func gorePanicAnalysisError(panicErr *gorePanicError) *Error {
+	return newError("Pygore Panic", fmt.Sprintf("Pygore paniced with a panic message: '%s'", panicErr.message), panicErr)
+}
+
+// Run the full analysis on a single Go binary, adding the features to the report through the feature writer.
+func (gi *Analyzer) analyseFile(ctx context.Context, features *featureWriter, contentFilePath string) (*goFileSummary, error) {
+	// Report structural problems up front, they often explain why the file can't be opened.
+	anomalies, err := validateStructure(contentFilePath)
+	if err != nil {
+		return nil, newError("Could not be read", "Failed to read the file to validate its structure", err)
+	}
+	features.report = newBinaryReport(features.labelPrefix)
+	for _, anomaly := range anomalies {
+		features.report.addWarning("malformed", anomaly.category, anomaly.detail)
+		features.addFeatureWithExtra("malformed", anomaly.detail, &featureOptions{Label: anomaly.category})
+	}
+	summary, err := gi.analyseGoFile(ctx, features, contentFilePath, anomalies)
+	if err != nil || summary.optOutMessage != "" {
+		return summary, err
+	}
+	features.flush()
+	features.writeReport()
+	return summary, nil
+}
+
+// Handle a file gore couldn't open according to the category of the failure.
+// Damaged files and architectures gore doesn't support are still analysed without gore, files that aren't
+// executables are scanned for Go structures, files that aren't Go are opted out and anything else is an error.
+func (gi *Analyzer) analyseUnopenedFile(ctx context.Context, features *featureWriter, contentFilePath string, anomalies []structuralAnomaly, classified *classifiedError) (*goFileSummary, error) {
+	switch classified.category {
+	case errorUnsupportedArch:
+		// Gore can't disassemble this architecture but the rest of the Go metadata is architecture independent.
+		optOutMessage := fmt.Sprintf("Gore paniced while trying to open the file with the panic message: '%s'", goreUnsupportedArchPanic)
+		return gi.analyseFallback(ctx, features, contentFilePath, goreUnsupportedArchPanic, optOutMessage)
+	case errorNotExecutable:
+		// Memory dumps and carved fragments have no valid header but may still hold the Go runtime structures.
+		optOutMessage := fmt.Sprintf("File could not be opened by pygore with message %s", classified.Error())
+		return gi.analyseMemoryDump(ctx, features, contentFilePath, optOutMessage)
+	case errorNotGo:
+		return &goFileSummary{optOutMessage: fmt.Sprintf("Not a go binary, %s.", classified.Error())}, nil
+	case errorCorrupted:
+		if classified.peSymbolTable {
+			malformedMessage := fmt.Sprintf("PE file was corrupted and it's header couldn't be read with error %s", classified.Error())
+			features.report.addWarning("malformed", "", malformedMessage)
+			features.addFeature("malformed", malformedMessage)
+			// The Go metadata doesn't need the COFF symbol table, so analyse a copy without it.
+			repairedPath, repairErr := writeRepairedPE(contentFilePath)
+			if repairErr == nil {
+				defer os.Remove(repairedPath)
+				return gi.analyseGoFile(ctx, features, repairedPath, anomalies)
+			}
+			log.Printf("failed to repair the corrupted PE file %v", repairErr)
+		}
+		// Corrupted headers stop gore opening the file, but the Go metadata can still be found without them.
+		optOutMessage := fmt.Sprintf("Malformed file could not be opened by pygore with message %s", classified.Error())
+		return gi.analyseFallback(ctx, features, contentFilePath, "Malformed file could not be opened: "+classified.Error(), optOutMessage)
+	}
+	var panicErr *gorePanicError
+	if errors.As(classified, &panicErr) {
+		return nil, gorePanicAnalysisError(panicErr)
+	}
+	return nil, newError("Could not be opened", "Pygore could not open the file", classified.err)
+}
+
+// Open the file with gore and extract the Go metadata, falling back to scanning the file when
+// gore can't open it.
+func (gi *Analyzer) analyseGoFile(ctx context.Context, features *featureWriter, contentFilePath string, anomalies []structuralAnomaly) (*goFileSummary, error) {
+	goFile, err := callGore(analysisStageOpen, contentFilePath, func() (*gore.GoFile, error) { return gore.Open(contentFilePath) })
+	if err != nil {
+		classified := classifyOpenError(err, contentFilePath)
+		// Structural anomalies explain failures that don't match a known cause.
+		if classified.category == errorInternalBug && len(anomalies) > 0 {
+			classified.category = errorCorrupted
+		}
+		return gi.analyseUnopenedFile(ctx, features, contentFilePath, anomalies, classified)
+	}
+	session := &goreSession{goFile: goFile, contentFilePath: contentFilePath}
+	// Every gore stage can build the pclntab, which runs out of memory on a crafted function count and can't be
+	// recovered from, so those files are analysed without gore. So are files that couldn't be checked in time.
+	stageCtx, cancelStage := gi.startStage(ctx, analysisStagePclntabCheck)
+	_, err = callGoreContext(stageCtx, session, analysisStagePclntabCheck, func() (struct{}, error) {
+		return struct{}{}, checkGorePclntab(goFile, contentFilePath)
+	})
+	cancelStage()
+	if err != nil {
+		session.close()
+		if isStageStopped(err) {
+			addTruncatedStage(features, analysisStagePclntabCheck, err)
+		}
+		optOutMessage := fmt.Sprintf("Malformed file could not be analysed by pygore with message %s", err)
+		return gi.analyseFallback(ctx, features, contentFilePath, "Malformed pclntab could not be read: "+err.Error(), optOutMessage)
+	}
+	defer session.close()
+
+	stageCtx, cancelStage = gi.startStage(ctx, analysisStageCompilerVersion)
+	compilerVersion, err := callGoreContext(stageCtx, session, analysisStageCompilerVersion, goFile.GetCompilerVersion)
+	cancelStage()
+	buildInfo, buildInfoErr := debugBuildInfo.ReadFile(contentFilePath)
+	summary := &goFileSummary{}
+	noVersion := err == nil || (!isStageStopped(err) && classifyError(err).category == errorNotGo)
+	switch {
+	case err == nil && compilerVersion != nil:
+		summary.goVersion = compilerVersion.Name
+	case noVersion && buildInfoErr == nil:
+		// Gore only knows the Go versions released before it, newer binaries still name theirs in the build info.
+		summary.goVersion = buildInfo.GoVersion
+	case noVersion:
+		return &goFileSummary{optOutMessage: "Not a go binary, no go version found."}, nil
+	default:
+		// Gore opened the file so carry on with the other stages without the version.
+		addStageError(features, analysisStageCompilerVersion, err)
+	}
+
+	yaraRuleSource := &yaraSource{
+		buildID:         goFile.BuildID,
+		goVersion:       summary.goVersion,
+		libraryPackages: map[string]struct{}{},
+	}
+
+	buildSettings := map[string]string{}
+	err = buildInfoErr
+	// Binaries from before go1.18 have no build info, so only record other failures.
+	if err != nil && classifyError(err).category.outcome() != outcomeOptOut {
+		addAnalysisError(features, analysisStageBuildInfo, err)
+	}
+	// Extract build info if the file is a valid go binary.
+	if err == nil {
+		features.report.setBuildInfo(buildInfo)
+		yaraRuleSource.mainModule = buildInfo.Main.Path
+		for _, dep := range buildInfo.Deps {
+			summary.modules = append(summary.modules, dep.Path+"@"+dep.Version)
+		}
+		for _, s := range buildInfo.Settings {
+			buildSettings[s.Key] = s.Value
+			features.addFeatureWithExtra("go_compiler_flag", s.Value, &featureOptions{
+				Label: s.Key,
+			})
+		}
+	}
+
+	addBuildModeFeatures(features, detectBuildMode(contentFilePath, buildSettings))
+
+	// The pclntab is only used to look for cgo stubs and name the functions gore splits wrongly, so carry on
+	// without it if it can't be read.
+	var pclntab *gosym.Table
+	if gi.settings.stageEnabled(analysisStagePclntab) {
+		stageCtx, cancelStage = gi.startStage(ctx, analysisStagePclntab)
+		pclntab, err = callGoreContext(stageCtx, session, analysisStagePclntab, goFile.PCLNTab)
+		cancelStage()
+		if err != nil {
+			addStageError(features, analysisStagePclntab, err)
+		}
+	}
+	addCgoFeatures(features, detectCgo(contentFilePath, buildSettings, pclntab))
+
+	// Get core compiler information.
+	// Prefer the version gore found to the build info, it is also available in binaries from before go1.18.
+	if summary.goVersion != "" {
+		features.report.GoVersion = summary.goVersion
+	}
+	features.report.BuildID = goFile.BuildID
+	features.addFeature("go_build_id", goFile.BuildID)
+	if goFile.BuildInfo != nil && goFile.BuildInfo.Compiler != nil {
+		features.addFeature("go_compiler_version", goFile.BuildInfo.Compiler.Name)
+		features.addFeature("go_compiler_timestamp", goFile.BuildInfo.Compiler.Timestamp)
+	} else if buildInfoErr == nil {
+		features.addFeature("go_compiler_version", buildInfo.GoVersion)
+	}
+
+	// The packages are walked within the package stage's budget.
+	packagesCtx := ctx
+	var packageList []*gore.Package
+	if gi.settings.stageEnabled(analysisStagePackages) {
+		var cancelPackages context.CancelFunc
+		packagesCtx, cancelPackages = gi.startStage(ctx, analysisStagePackages)
+		defer cancelPackages()
+		packageList, err = callGoreContext(packagesCtx, session, analysisStagePackages, goFile.GetPackages)
+		if err != nil {
+			addStageError(features, analysisStagePackages, err)
+		}
+	}
+	// Get all the vendor package names.
+	prefixes := gi.settings.userCodePrefixes()
+	var vendorPackages []*gore.Package
+	if gi.settings.stageEnabled(analysisStageVendors) {
+		vendorCtx, cancelVendors := gi.startStage(ctx, analysisStageVendors)
+		defer cancelVendors()
+		vendorPackages, err = callGoreContext(vendorCtx, session, analysisStageVendors, goFile.GetVendors)
+		if err != nil {
+			addStageError(features, analysisStageVendors, err)
+		}
+		// Gore can put forks of libraries and copies of the standard library in the wrong list, so the settings can move them.
+		var otherPackages []*gore.Package
+		if prefixes != nil && len(prefixes.user) > 0 {
+			for _, getPackages := range []func() ([]*gore.Package, error){goFile.GetSTDLib, goFile.GetUnknown} {
+				packages, err := callGoreContext(vendorCtx, session, analysisStageVendors, getPackages)
+				if err != nil {
+					addStageError(features, analysisStageVendors, err)
+				}
+				otherPackages = append(otherPackages, packages...)
+			}
+		}
+		packageList, vendorPackages = prefixes.reclassify(packageList, vendorPackages, otherPackages)
+	}
+
+	goPackageSet := map[string]interface{}{}
+	generics := &genericFunctions{}
+	closures := &closureTree{}
+	// Get all the functions and methods in this package.
+	for _, pkg := range packageList {
+		if packagesCtx.Err() != nil {
+			addTruncatedStage(features, analysisStagePackages, packagesCtx.Err())
+			break
+		}
+		packagePath := goPackagePath(pkg.Name)
+		reportedPackage := features.report.addPackage(packagePath, packageKindUser, pkg.Filepath)
+		if gi.settings.groupEnabled(groupFiles) {
+			sourceFiles, _ := callGoreContext(packagesCtx, session, analysisStagePackages, func() ([]*gore.SourceFile, error) { return goFile.GetSourceFiles(pkg), nil })
+			for _, sourceFile := range sourceFiles {
+				reportedPackage.Files = append(reportedPackage.Files, sourceFile.Name)
+			}
+		}
+		features.addFeature("go_package", packagePath)
+		goPackageSet[packagePath] = nil
+		yaraRuleSource.packages = append(yaraRuleSource.packages, packagePath)
+		if pkg.Filepath != "." {
+			features.addFeature("go_file", pkg.Filepath)
+		}
+		// The functions and methods are only walked when their features are written.
+		functions, methods := pkg.Functions, pkg.Methods
+		if !gi.settings.groupEnabled(groupFunctions) {
+			functions = nil
+		}
+		if !gi.settings.groupEnabled(groupMethods) {
+			methods = nil
+		}
+		// Add package functions
+		for _, pkgFunc := range functions {
+			reportedPackage.Functions = append(reportedPackage.Functions, Function{Name: pkgFunc.Name, Start: pkgFunc.Offset, End: pkgFunc.End})
+			yaraRuleSource.functions = append(yaraRuleSource.functions, pkgFunc.PackageName+"."+pkgFunc.Name)
+			// Instantiations of a generic function are all written under its base name.
+			function := parseGoreFunction(pclntab, pkgFunc.PackageName, "", pkgFunc.Name, pkgFunc.Offset)
+			generics.add(function)
+			closures.add(function, pkgFunc.Offset, pkgFunc.End)
+			functionName := pkgFunc.Name
+			if function.typeArguments != "" {
+				functionName = function.functionName()
+			}
+			features.addFeatureWithExtra(
+				"go_package_function",
+				functionName,
+				&featureOptions{
+					Label:  pkgFunc.PackageName,
+					Offset: pkgFunc.Offset,
+					Size:   pkgFunc.End - pkgFunc.Offset,
+				},
+			)
+		}
+		// Add package methods
+		for _, pkgMethods := range methods {
+			method := parseGoreFunction(pclntab, pkgMethods.PackageName, pkgMethods.Receiver, pkgMethods.Name, pkgMethods.Offset)
+			generics.add(method)
+			closures.add(method, pkgMethods.Offset, pkgMethods.End)
+			reportedPackage.Methods = append(reportedPackage.Methods, Function{
+				Name:     method.functionName(),
+				Receiver: method.receiverName(),
+				Start:    pkgMethods.Offset,
+				End:      pkgMethods.End,
+			})
+			features.addFeatureWithExtra(
+				"go_package_method",
+				method.functionName(),
+				&featureOptions{
+					Label:  method.packagePath,
+					Offset: pkgMethods.Offset,
+					Size:   pkgMethods.End - pkgMethods.Offset,
+				},
+			)
+			addMethodReceiverFeature(features, method, pkgMethods.Offset, pkgMethods.End)
+		}
+	}
+	addGenericFunctionFeatures(features, generics)
+	addClosureFeatures(features, closures)
+	for _, vendorPackage := range vendorPackages {
+		vendorPath := goPackagePath(vendorPackage.Name)
+		features.report.addPackage(vendorPath, packageKindLibrary, vendorPackage.Filepath)
+		yaraRuleSource.libraryPackages[vendorPath] = struct{}{}
+		features.addFeature("go_vendor_package", vendorPath)
+	}
+
+	// Add User definied GoTypes.
+	// Finding the types is the slowest stage, so skip it when none of its features are wanted.
+	// The types are walked within the type stage's budget.
+	typesCtx := ctx
+	var goTypes []*gore.GoType
+	if gi.settings.stageEnabled(analysisStageTypes) {
+		var cancelTypes context.CancelFunc
+		typesCtx, cancelTypes = gi.startStage(ctx, analysisStageTypes)
+		defer cancelTypes()
+		goTypes, err = callGoreContext(typesCtx, session, analysisStageTypes, goFile.GetTypes)
+		if err != nil {
+			addStageError(features, analysisStageTypes, err)
+		}
+	}
+	for _, goType := range goTypes {
+		if typesCtx.Err() != nil {
+			addTruncatedStage(features, analysisStageTypes, typesCtx.Err())
+			break
+		}
+		/*
+			This will get all types defined in the binary including ones from standard libraries
+			Somehow the get_packages() function only gets user defined packages, so we check the
+			type's packagePath matches one of these to only get the user defined types as well
+		*/
+		_, ok := goPackageSet[goType.PackagePath]
+		if user, matched := prefixes.classify(goType.PackagePath); matched {
+			ok = user
+		}
+		if !ok {
+			continue
+		}
+		yaraRuleSource.types = append(yaraRuleSource.types, goType.Name)
+		features.report.addType(newReportType(goType))
+
+		features.addFeatureWithExtra(
+			"go_type",
+			goType.Name,
+			&featureOptions{
+				Label:  goType.Kind.String(),
+				Offset: goType.Addr,
+				Size:   uint64(goType.Length),
+			},
+		)
+		/*
+			Type methods also have an offset value however it is an offset relative to
+			a variable location in the binary, so excluding it in the below feature.
+			Some of the methods below may be duplicated by the go_package_method
+			feature anyway, which does extract the file offset
+		*/
+		for _, goTypeMethod := range goType.Methods {
+			features.addFeatureWithExtra(
+				"go_type_method",
+				goTypeMethod.Name,
+				&featureOptions{Label: goType.Name},
+			)
+		}
+	}
+
+	// Attach a candidate YARA rule built from the most distinctive artefacts found above.
+	yaraRule := buildYaraRule("goinfo_"+features.labelPrefix+goFile.BuildID, yaraRuleSource)
+	if yaraRule != "" {
+		features.result.YaraRules = append(features.result.YaraRules, yaraRule)
+	}
+	return summary, nil
+}
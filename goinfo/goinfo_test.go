@@ -0,0 +1,66 @@
+package goinfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCallGoreRecoversPanics(t *testing.T) {
+	logged := &bytes.Buffer{}
+	log.SetOutput(logged)
+	defer log.SetOutput(os.Stderr)
+	_, err := callGore(analysisStagePackages, "test.bin", func() (int, error) { panic("Unsupported architecture") })
+	var panicErr *gorePanicError
+	if !errors.As(err, &panicErr) || panicErr.message != "Unsupported architecture" {
+		t.Errorf("expected the string panic to be returned, got %v", err)
+	}
+	if !strings.HasSuffix(logged.String(), "Gore panicked in the packages stage of test.bin: Unsupported architecture\n") {
+		t.Errorf("expected the panic to be logged with the stage and file, got %q", logged.String())
+	}
+	_, err = callGore(analysisStagePackages, "test.bin", func() (int, error) {
+		var values []int
+		return values[1], nil
+	})
+	if !errors.As(err, &panicErr) || !strings.Contains(panicErr.message, "index out of range") {
+		t.Errorf("expected the runtime error panic to be returned, got %v", err)
+	}
+	value, err := callGore(analysisStagePackages, "test.bin", func() (int, error) { return 7, nil })
+	if value != 7 || err != nil {
+		t.Errorf("expected the result without a panic, got %d %v", value, err)
+	}
+}
+
+func TestCallGoreConcurrentPanics(t *testing.T) {
+	// Each call must only ever see its own panic.
+	var wg sync.WaitGroup
+	errs := make([]error, 50)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = callGore(analysisStagePackages, "test.bin", func() (int, error) {
+				if i%2 == 0 {
+					panic(fmt.Sprintf("panic %d", i))
+				}
+				return i, nil
+			})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		var panicErr *gorePanicError
+		if i%2 == 1 {
+			if err != nil {
+				t.Errorf("call %d should not have panicked, got %v", i, err)
+			}
+		} else if !errors.As(err, &panicErr) || panicErr.message != fmt.Sprintf("panic %d", i) {
+			t.Errorf("call %d got the wrong panic %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package goinfo
+
+import (
+	"context"
+	"debug/macho"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Go architecture names for the Mach-O CPU types, so slice labels match GOARCH.
+var machoCpuGoArch = map[macho.Cpu]string{
+	macho.Cpu386:   "386",
+	macho.CpuAmd64: "amd64",
+	macho.CpuArm:   "arm",
+	macho.CpuArm64: "arm64",
+	macho.CpuPpc:   "ppc",
+	macho.CpuPpc64: "ppc64",
+}
+
+// A single architecture of a universal Mach-O file, written out to its own file.
+type machoSlice struct {
+	arch string
+	path string
+}
+
+// Get the Go architecture name for a Mach-O CPU type.
+func machoSliceArch(cpu macho.Cpu) string {
+	arch, ok := machoCpuGoArch[cpu]
+	if !ok {
+		return strings.ToLower(strings.TrimPrefix(cpu.String(), "Cpu"))
+	}
+	return arch
+}
+
+// Write each architecture slice of a universal Mach-O file to a temporary file.
+// Nil is returned if the file isn't a universal Mach-O.
+func extractMachoSlices(filePath string) ([]machoSlice, error) {
+	rawFile, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer rawFile.Close()
+
+	fatFile, err := macho.NewFatFile(rawFile)
+	// Java class files share the universal Mach-O magic, so any parse error means this is not a universal binary.
+	if err != nil {
+		return nil, nil
+	}
+	defer fatFile.Close()
+
+	slices := []machoSlice{}
+	archCounts := map[string]int{}
+	for _, fatArch := range fatFile.Arches {
+		arch := machoSliceArch(fatArch.Cpu)
+		// Slices can share a CPU type with a different subtype (e.g arm64 and arm64e).
+		archCounts[arch]++
+		if archCounts[arch] > 1 {
+			arch = fmt.Sprintf("%s_%d", arch, archCounts[arch])
+		}
+		slicePath, err := writeMachoSlice(rawFile, fatArch.Offset, fatArch.Size)
+		if err != nil {
+			removeMachoSlices(slices)
+			return nil, err
+		}
+		slices = append(slices, machoSlice{arch: arch, path: slicePath})
+	}
+	return slices, nil
+}
+
+// Copy a slice of a universal Mach-O file into a new temporary file and return its path.
+func writeMachoSlice(rawFile *os.File, offset uint32, size uint32) (string, error) {
+	sliceFile, err := os.CreateTemp("", "goinfo-macho-slice-")
+	if err != nil {
+		return "", err
+	}
+	defer sliceFile.Close()
+	written, err := io.Copy(sliceFile, io.NewSectionReader(rawFile, int64(offset), int64(size)))
+	if err == nil && written != int64(size) {
+		err = errors.New("universal Mach-O slice extends past the end of the file")
+	}
+	if err != nil {
+		os.Remove(sliceFile.Name())
+		return "", err
+	}
+	return sliceFile.Name(), nil
+}
+
+// Delete the temporary files created for each slice.
+func removeMachoSlices(slices []machoSlice) {
+	for _, slice := range slices {
+		os.Remove(slice.path)
+	}
+}
+
+// Run the full analysis on every slice of a universal Mach-O file, labelling the features with the slice architecture.
+// Returns the reason to opt out when none of the slices are Go.
+func (gi *Analyzer) analyseMachoSlices(ctx context.Context, result *Report, slices []machoSlice) (string, error) {
+	features := &featureWriter{result: result, settings: gi.settings}
+	summaries := map[string]*goFileSummary{}
+	optOutMessage := ""
+	for _, slice := range slices {
+		summary, err := gi.analyseFile(ctx, &featureWriter{result: result, labelPrefix: slice.arch, settings: gi.settings}, slice.path)
+		if err != nil {
+			return "", err
+		}
+		// A universal file can mix Go and non Go slices, only opt out if none of them are Go.
+		if summary.optOutMessage != "" {
+			if optOutMessage == "" {
+				optOutMessage = summary.optOutMessage
+			}
+			continue
+		}
+		summaries[slice.arch] = summary
+		features.addFeatureWithExtra("go_macho_slice", slice.arch, &featureOptions{Label: summary.goVersion})
+	}
+	if len(summaries) == 0 {
+		return optOutMessage, nil
+	}
+
+	for _, mismatch := range compareMachoSlices(summaries) {
+		features.addFeatureWithExtra("go_macho_slice_mismatch", mismatch.field, &featureOptions{Label: mismatch.detail})
+	}
+	return "", nil
+}
+
+// A difference between the Go builds in the slices of a universal Mach-O.
+type machoSliceMismatch struct {
+	// Either go_version or modules.
+	field  string
+	detail string
+}
+
+// Compare the Go version and module set of each slice, returning any differences.
+func compareMachoSlices(summaries map[string]*goFileSummary) []machoSliceMismatch {
+	arches := []string{}
+	for arch := range summaries {
+		arches = append(arches, arch)
+	}
+	sort.Strings(arches)
+
+	mismatches := []machoSliceMismatch{}
+	versions := []string{}
+	versionSet := map[string]struct{}{}
+	for _, arch := range arches {
+		versions = append(versions, arch+"="+summaries[arch].goVersion)
+		versionSet[summaries[arch].goVersion] = struct{}{}
+	}
+	if len(versionSet) > 1 {
+		mismatches = append(mismatches, machoSliceMismatch{field: "go_version", detail: strings.Join(versions, ",")})
+	}
+
+	// Count how many slices each module appears in, any module not in every slice is a difference.
+	moduleCounts := map[string]int{}
+	for _, arch := range arches {
+		for _, module := range summaries[arch].modules {
+			moduleCounts[module]++
+		}
+	}
+	moduleDifferences := []string{}
+	for _, arch := range arches {
+		for _, module := range summaries[arch].modules {
+			if moduleCounts[module] != len(arches) {
+				moduleDifferences = append(moduleDifferences, arch+"="+module)
+			}
+		}
+	}
+	if len(moduleDifferences) > 0 {
+		sort.Strings(moduleDifferences)
+		mismatches = append(mismatches, machoSliceMismatch{field: "modules", detail: strings.Join(moduleDifferences, ",")})
+	}
+	return mismatches
+}
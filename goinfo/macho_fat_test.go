@@ -0,0 +1,99 @@
+package goinfo
+
+import (
+	"debug/macho"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Combine the contents of thin Mach-O files into a universal Mach-O file.
+func universalMachoData(cpus []macho.Cpu, thinData [][]byte) []byte {
+	const sliceAlignment = 1 << 14
+	header := []uint32{macho.MagicFat, uint32(len(thinData))}
+	offset := uint32(sliceAlignment)
+	for i, data := range thinData {
+		header = append(header, uint32(cpus[i]), 0, offset, uint32(len(data)), 14)
+		offset += (uint32(len(data)) + sliceAlignment - 1) &^ (sliceAlignment - 1)
+	}
+	universal := make([]byte, offset)
+	for i, value := range header {
+		binary.BigEndian.PutUint32(universal[i*4:], value)
+	}
+	for i, data := range thinData {
+		copy(universal[header[2+i*5+2]:], data)
+	}
+	return universal
+}
+
+// Combine thin Mach-O files into a universal Mach-O file.
+func buildUniversalMacho(t *testing.T, cpus []macho.Cpu, thinPaths []string) string {
+	t.Helper()
+	thinData := [][]byte{}
+	for _, thinPath := range thinPaths {
+		data, err := os.ReadFile(thinPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		thinData = append(thinData, data)
+	}
+	universalPath := filepath.Join(t.TempDir(), "universal.bin")
+	err := os.WriteFile(universalPath, universalMachoData(cpus, thinData), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return universalPath
+}
+
+func TestExtractMachoSlices(t *testing.T) {
+	amd64Path := buildTestProgram(t, helloWorldSource, []string{"GOOS=darwin", "GOARCH=amd64"})
+	arm64Path := buildTestProgram(t, helloWorldSource, []string{"GOOS=darwin", "GOARCH=arm64"})
+	universalPath := buildUniversalMacho(t, []macho.Cpu{macho.CpuAmd64, macho.CpuArm64}, []string{amd64Path, arm64Path})
+
+	slices, err := extractMachoSlices(universalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeMachoSlices(slices)
+	if len(slices) != 2 || slices[0].arch != "amd64" || slices[1].arch != "arm64" {
+		t.Fatalf("unexpected slices %v", slices)
+	}
+	for i, thinPath := range []string{amd64Path, arm64Path} {
+		expected, _ := os.ReadFile(thinPath)
+		actual, _ := os.ReadFile(slices[i].path)
+		if string(expected) != string(actual) {
+			t.Errorf("slice %s does not match the original thin binary", slices[i].arch)
+		}
+	}
+
+	// Thin files are not universal so are analysed as normal.
+	slices, err = extractMachoSlices(amd64Path)
+	if err != nil || slices != nil {
+		t.Errorf("expected no slices for a thin Mach-O, got %v %v", slices, err)
+	}
+}
+
+func TestCompareMachoSlices(t *testing.T) {
+	mismatches := compareMachoSlices(map[string]*goFileSummary{
+		"arm64": {goVersion: "go1.22.1", modules: []string{"golang.org/x/sys@v0.1.0", "github.com/evil/c2@v1.0.0"}},
+		"amd64": {goVersion: "go1.21.0", modules: []string{"golang.org/x/sys@v0.1.0"}},
+	})
+	if len(mismatches) != 2 {
+		t.Fatalf("expected 2 mismatches, got %v", mismatches)
+	}
+	if mismatches[0].field != "go_version" || mismatches[0].detail != "amd64=go1.21.0,arm64=go1.22.1" {
+		t.Errorf("unexpected go version mismatch %v", mismatches[0])
+	}
+	if mismatches[1].field != "modules" || mismatches[1].detail != "arm64=github.com/evil/c2@v1.0.0" {
+		t.Errorf("unexpected module mismatch %v", mismatches[1])
+	}
+
+	mismatches = compareMachoSlices(map[string]*goFileSummary{
+		"arm64": {goVersion: "go1.22.1", modules: []string{"golang.org/x/sys@v0.1.0"}},
+		"amd64": {goVersion: "go1.22.1", modules: []string{"golang.org/x/sys@v0.1.0"}},
+	})
+	if len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %v", mismatches)
+	}
+}
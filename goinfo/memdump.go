@@ -0,0 +1,493 @@
+package goinfo
+
+import (
+	"bytes"
+	"context"
+	"debug/gosym"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// Reason reported in go_analysis_fallback when the Go structures were found by scanning raw memory.
+const memoryScanReason = "No executable header, Go runtime structures located by scanning memory"
+
+// Marker at the start of the build info blob written by the Go linker.
+var goBuildInfoMarker = []byte("\xff Go buildinf:")
+
+// Type names recovered from type descriptors, optionally a pointer to a named type.
+var memoryTypeNameRegex = regexp.MustCompile(`^\*?[A-Za-z_][A-Za-z0-9_]*\.[A-Za-z_][A-Za-z0-9_]*(\[.+\])?$`)
+
+// A Go module located in a memory dump or carved fragment.
+type memoryModule struct {
+	pclntabOffset int
+	pclntabData   []byte
+	pclntab       *gosym.Table
+	byteOrder     binary.ByteOrder
+	ptrSize       int
+	// Offset of the runtime moduledata, -1 if it wasn't found.
+	moduledataOffset int
+	// Address of the first byte of the data, only known if the moduledata was found.
+	baseAddress uint64
+	textStart   uint64
+	typesStart  uint64
+	typesEnd    uint64
+}
+
+// A type recovered from the type descriptors of a memory module.
+type memoryType struct {
+	name    string
+	kind    string
+	address uint64
+}
+
+// Positions of fields in the runtime moduledata, in pointer sized words.
+type moduledataLayout struct {
+	text   int
+	types  int
+	etypes int
+}
+
+// Get the moduledata layouts that may have been used with a pclntab version, in the order to try them.
+func moduledataLayouts(magic uint32) []moduledataLayout {
+	switch magic {
+	case pclntabMagic12:
+		return []moduledataLayout{{text: 12, types: 25, etypes: 26}}
+	case pclntabMagic116, pclntabMagic118:
+		return []moduledataLayout{{text: 22, types: 35, etypes: 36}}
+	default:
+		// go1.20 added the coverage counters, later versions added typedesclen between types and etypes.
+		return []moduledataLayout{{text: 22, types: 37, etypes: 38}, {text: 22, types: 37, etypes: 39}}
+	}
+}
+
+// Read a pointer sized word, 0 is returned if it is out of range.
+func readMemoryWord(data []byte, offset int, ptrSize int, byteOrder binary.ByteOrder) uint64 {
+	if offset < 0 || offset+ptrSize > len(data) {
+		return 0
+	}
+	if ptrSize == 4 {
+		return uint64(byteOrder.Uint32(data[offset:]))
+	}
+	return byteOrder.Uint64(data[offset:])
+}
+
+// Convert an address to an offset into the data, using the inferred base address.
+func (m *memoryModule) addressToOffset(address uint64, dataLength int) (int, bool) {
+	if m.moduledataOffset < 0 || address < m.baseAddress || address-m.baseAddress >= uint64(dataLength) {
+		return 0, false
+	}
+	return int(address - m.baseAddress), true
+}
+
+// Where the runtime moduledata pointing at a pclntab is, and the base address it implies.
+// The offset is -1 if no moduledata was found.
+type moduledataLocation struct {
+	offset      int
+	baseAddress uint64
+}
+
+// Find the runtime moduledata that points at the pclntab at each of pclntabOffsets.
+// Every candidate is looked for in a single pass over the data, so many candidates don't mean many scans.
+func findModuledatas(data []byte, pclntabOffsets []int) []moduledataLocation {
+	// The moduledata starts with a pointer to the pclntab, followed by slices pointing into it.
+	type moduledataPattern struct {
+		pclntabOffset     int
+		byteOrder         binary.ByteOrder
+		ptrSize           int
+		go12              bool
+		firstSliceOffset  uint64
+		secondSliceOffset uint64
+		secondSliceSlot   int
+	}
+	locations := make([]moduledataLocation, len(pclntabOffsets))
+	patterns := []*moduledataPattern{}
+	for i, pclntabOffset := range pclntabOffsets {
+		locations[i].offset = -1
+		header := data[pclntabOffset:]
+		byteOrder := pclntabByteOrder(header)
+		if byteOrder == nil {
+			patterns = append(patterns, nil)
+			continue
+		}
+		pattern := &moduledataPattern{pclntabOffset: pclntabOffset, byteOrder: byteOrder, ptrSize: int(header[7]), secondSliceSlot: 4}
+		switch byteOrder.Uint32(header) {
+		case pclntabMagic12:
+			// The pclntable slice then the ftab slice, which starts after the header.
+			pattern.go12 = true
+			pattern.secondSliceOffset = uint64(8 + pattern.ptrSize)
+			pattern.secondSliceSlot = 3
+		case pclntabMagic116:
+			pattern.firstSliceOffset = readMemoryWord(header, 8+2*pattern.ptrSize, pattern.ptrSize, byteOrder)
+			pattern.secondSliceOffset = readMemoryWord(header, 8+3*pattern.ptrSize, pattern.ptrSize, byteOrder)
+		default:
+			pattern.firstSliceOffset = readMemoryWord(header, 8+3*pattern.ptrSize, pattern.ptrSize, byteOrder)
+			pattern.secondSliceOffset = readMemoryWord(header, 8+4*pattern.ptrSize, pattern.ptrSize, byteOrder)
+		}
+		if pattern.secondSliceOffset == 0 {
+			pattern = nil
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	remaining := 0
+	for _, pattern := range patterns {
+		if pattern != nil {
+			remaining++
+		}
+	}
+	for offset := 0; offset+4 <= len(data) && remaining > 0; offset += 4 {
+		for i, pattern := range patterns {
+			if pattern == nil || locations[i].offset >= 0 || offset%pattern.ptrSize != 0 || offset+(pattern.secondSliceSlot+1)*pattern.ptrSize > len(data) {
+				continue
+			}
+			word := func(offset int) uint64 { return readMemoryWord(data, offset, pattern.ptrSize, pattern.byteOrder) }
+			pclntabAddress := word(offset)
+			if pclntabAddress < uint64(pattern.pclntabOffset) || pclntabAddress == 0 {
+				continue
+			}
+			if pattern.go12 {
+				// Slice length and capacity are equal for the pclntable.
+				if word(offset+pattern.ptrSize) == 0 || word(offset+pattern.ptrSize) != word(offset+2*pattern.ptrSize) {
+					continue
+				}
+			} else if word(offset+pattern.ptrSize) != pclntabAddress+pattern.firstSliceOffset {
+				continue
+			}
+			if word(offset+pattern.secondSliceSlot*pattern.ptrSize) != pclntabAddress+pattern.secondSliceOffset {
+				continue
+			}
+			locations[i] = moduledataLocation{offset: offset, baseAddress: pclntabAddress - uint64(pattern.pclntabOffset)}
+			remaining--
+		}
+	}
+	return locations
+}
+
+// Find the runtime moduledata that points at the pclntab at pclntabOffset.
+// The offset of the moduledata and the base address it implies are returned, the offset is -1 if none was found.
+func findModuledata(data []byte, pclntabOffset int) (int, uint64) {
+	location := findModuledatas(data, []int{pclntabOffset})[0]
+	return location.offset, location.baseAddress
+}
+
+// Read the text and etext addresses from a moduledata found with findModuledata.
+func moduledataTextRange(data []byte, pclntabOffset int, moduledataOffset int) (uint64, uint64) {
+	header := data[pclntabOffset:]
+	byteOrder := pclntabByteOrder(header)
+	if byteOrder == nil || moduledataOffset < 0 {
+		return 0, 0
+	}
+	ptrSize := int(header[7])
+	// The position of text is the same in every layout and etext always follows it.
+	textSlot := moduledataLayouts(byteOrder.Uint32(header))[0].text
+	text := readMemoryWord(data, moduledataOffset+textSlot*ptrSize, ptrSize, byteOrder)
+	etext := readMemoryWord(data, moduledataOffset+(textSlot+1)*ptrSize, ptrSize, byteOrder)
+	return text, etext
+}
+
+// Locate the Go module in raw bytes with no executable header, preferring the pclntab with a moduledata
+// pointing at it, then the one with the most functions.
+func findMemoryModule(data []byte) *memoryModule {
+	var best *memoryModule
+	candidates := findPclntabCandidates(data)
+	moduledatas := findModuledatas(data, candidates)
+	for i, candidate := range candidates {
+		header := data[candidate:]
+		module := &memoryModule{
+			pclntabOffset: candidate,
+			pclntabData:   header,
+			byteOrder:     pclntabByteOrder(header),
+			ptrSize:       int(header[7]),
+		}
+		module.moduledataOffset, module.baseAddress = moduledatas[i].offset, moduledatas[i].baseAddress
+		if module.moduledataOffset >= 0 {
+			module.textStart, _ = moduledataTextRange(data, candidate, module.moduledataOffset)
+			magic := module.byteOrder.Uint32(header)
+			for _, layout := range moduledataLayouts(magic) {
+				word := func(slot int) uint64 {
+					return readMemoryWord(data, module.moduledataOffset+slot*module.ptrSize, module.ptrSize, module.byteOrder)
+				}
+				typesStart, typesEnd := word(layout.types), word(layout.etypes)
+				_, startOk := module.addressToOffset(typesStart, len(data))
+				_, endOk := module.addressToOffset(typesEnd-1, len(data))
+				if startOk && endOk && typesStart < typesEnd {
+					module.typesStart = typesStart
+					module.typesEnd = typesEnd
+					break
+				}
+			}
+		}
+
+		table, err := parsePclntab(header, module.textStart)
+		if err != nil {
+			continue
+		}
+		module.pclntab = table
+		if best == nil || (module.moduledataOffset >= 0 && best.moduledataOffset < 0) ||
+			((module.moduledataOffset >= 0) == (best.moduledataOffset >= 0) && len(table.Funcs) > len(best.pclntab.Funcs)) {
+			best = module
+		}
+	}
+	return best
+}
+
+// Decode a name in the type descriptor data, returns false if it doesn't look like a valid name.
+func readMemoryTypeName(data []byte, offset int, varintLength bool) (string, bool) {
+	// Names are a flags byte then the length, which was 2 bytes big endian before go1.17.
+	if offset < 0 || offset+3 > len(data) || data[offset] >= 0x10 {
+		return "", false
+	}
+	var length uint64
+	lengthSize := 2
+	if varintLength {
+		var n int
+		length, n = binary.Uvarint(data[offset+1:])
+		if n <= 0 {
+			return "", false
+		}
+		lengthSize = n
+	} else {
+		length = uint64(binary.BigEndian.Uint16(data[offset+1:]))
+	}
+	start := offset + 1 + lengthSize
+	if length == 0 || length > 512 || uint64(start)+length > uint64(len(data)) {
+		return "", false
+	}
+	return string(data[start : start+int(length)]), true
+}
+
+// Scan the type descriptor region of a memory module for named types.
+// Type descriptors are found by checking every pointer aligned position for a plausible descriptor.
+func scanMemoryTypes(data []byte, module *memoryModule) []memoryType {
+	typesOffset, ok := module.addressToOffset(module.typesStart, len(data))
+	if !ok || module.typesEnd <= module.typesStart {
+		return nil
+	}
+	typesEnd := typesOffset + int(module.typesEnd-module.typesStart)
+	ptrSize := module.ptrSize
+	// go1.16 shares its pclntab magic with go1.17 but still used 2 byte name lengths.
+	magic := module.byteOrder.Uint32(module.pclntabData)
+	readName := func(nameOffset int) (string, bool) {
+		name, ok := readMemoryTypeName(data[:typesEnd], nameOffset, magic != pclntabMagic12)
+		if !ok && magic == pclntabMagic116 {
+			name, ok = readMemoryTypeName(data[:typesEnd], nameOffset, false)
+		}
+		return name, ok
+	}
+
+	// The descriptor is size, ptrdata, hash, tflag, align, fieldAlign, kind, equal, gcdata, str then ptrToThis.
+	flagsOffset := 2*ptrSize + 4
+	strOffset := 4*ptrSize + 8
+	seen := map[string]struct{}{}
+	types := []memoryType{}
+	for offset := typesOffset; offset+strOffset+8 <= typesEnd; offset += ptrSize {
+		tflag, align, fieldAlign, kind := data[offset+flagsOffset], data[offset+flagsOffset+1], data[offset+flagsOffset+2], data[offset+flagsOffset+3]&0x1f
+		if tflag >= 0x40 || kind == 0 || kind > 26 || !isMemoryTypeAlignment(align) || !isMemoryTypeAlignment(fieldAlign) || fieldAlign > align {
+			continue
+		}
+		nameOffset := int32(module.byteOrder.Uint32(data[offset+strOffset:]))
+		if nameOffset <= 0 || typesOffset+int(nameOffset) >= typesEnd {
+			continue
+		}
+		name, ok := readName(typesOffset + int(nameOffset))
+		if !ok {
+			continue
+		}
+		// Named types have the name of the pointer to them with the star flagged as extra.
+		const tflagExtraStar, tflagNamed = 1 << 1, 1 << 2
+		if tflag&tflagExtraStar != 0 {
+			name = strings.TrimPrefix(name, "*")
+		} else if tflag&tflagNamed == 0 && reflect.Kind(kind) != reflect.Pointer {
+			continue
+		}
+		if !memoryTypeNameRegex.MatchString(name) {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		types = append(types, memoryType{
+			name:    name,
+			kind:    reflect.Kind(kind).String(),
+			address: module.baseAddress + uint64(offset),
+		})
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].name < types[j].name })
+	return types
+}
+
+func isMemoryTypeAlignment(alignment uint8) bool {
+	return alignment == 1 || alignment == 2 || alignment == 4 || alignment == 8
+}
+
+// Find and parse the build info blob in raw bytes.
+// Older Go versions store pointers to the strings, which can only be followed if the base address is known.
+func findMemoryBuildInfo(data []byte, module *memoryModule) *debug.BuildInfo {
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], goBuildInfoMarker)
+		if index < 0 {
+			return nil
+		}
+		offset += index
+		if buildInfo := parseMemoryBuildInfo(data, offset, module); buildInfo != nil {
+			return buildInfo
+		}
+		offset += len(goBuildInfoMarker)
+	}
+}
+
+func parseMemoryBuildInfo(data []byte, offset int, module *memoryModule) *debug.BuildInfo {
+	// The header is the marker, pointer size and flags padded to 32 bytes.
+	if offset+32 > len(data) {
+		return nil
+	}
+	ptrSize := int(data[offset+14])
+	flags := data[offset+15]
+	var version, modInfo string
+	if flags&2 != 0 {
+		// Strings are stored inline after the header with varint lengths.
+		readString := func(position int) (string, int, bool) {
+			length, n := binary.Uvarint(data[position:])
+			if n <= 0 || length > uint64(len(data)-position-n) {
+				return "", 0, false
+			}
+			return string(data[position+n : position+n+int(length)]), position + n + int(length), true
+		}
+		var next int
+		var ok bool
+		version, next, ok = readString(offset + 32)
+		if !ok {
+			return nil
+		}
+		modInfo, _, ok = readString(next)
+		if !ok {
+			return nil
+		}
+	} else {
+		if module == nil || (ptrSize != 4 && ptrSize != 8) {
+			return nil
+		}
+		var byteOrder binary.ByteOrder = binary.LittleEndian
+		if flags&1 != 0 {
+			byteOrder = binary.BigEndian
+		}
+		readString := func(pointerOffset int) (string, bool) {
+			headerOffset, ok := module.addressToOffset(readMemoryWord(data, pointerOffset, ptrSize, byteOrder), len(data))
+			if !ok {
+				return "", false
+			}
+			stringOffset, ok := module.addressToOffset(readMemoryWord(data, headerOffset, ptrSize, byteOrder), len(data))
+			length := readMemoryWord(data, headerOffset+ptrSize, ptrSize, byteOrder)
+			if !ok || length > uint64(len(data))-uint64(stringOffset) {
+				return "", false
+			}
+			return string(data[stringOffset : stringOffset+int(length)]), true
+		}
+		var ok bool
+		version, ok = readString(offset + 16)
+		if !ok {
+			return nil
+		}
+		modInfo, _ = readString(offset + 16 + ptrSize)
+	}
+	if !strings.HasPrefix(version, "go") && !strings.HasPrefix(version, "devel") {
+		return nil
+	}
+	// The module info is wrapped in 16 byte sentinels.
+	if len(modInfo) >= 33 && modInfo[len(modInfo)-17] == '\n' {
+		modInfo = modInfo[16 : len(modInfo)-16]
+	}
+	buildInfo, err := debug.ParseBuildInfo(modInfo)
+	if err != nil {
+		buildInfo = &debug.BuildInfo{}
+	}
+	buildInfo.GoVersion = version
+	return buildInfo
+}
+
+// Analyse a memory dump or carved fragment that has no valid executable header.
+// The pclntab, moduledata, type descriptors and build info are found by scanning the raw bytes.
+// The opt out message is returned in the summary if no pclntab could be found.
+func (gi *Analyzer) analyseMemoryDump(ctx context.Context, features *featureWriter, contentFilePath string, optOutMessage string) (*goFileSummary, error) {
+	data, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return nil, newError("Could not be read", "Failed to read the file to scan for Go structures", err)
+	}
+	module := findMemoryModule(data)
+	if module == nil {
+		return &goFileSummary{optOutMessage: optOutMessage}, nil
+	}
+
+	features.report.setFallback(memoryScanReason)
+	features.addFeature("go_analysis_fallback", memoryScanReason)
+	if module.moduledataOffset >= 0 {
+		features.addFeatureWithExtra("go_memory_base_address", fmt.Sprintf("0x%x", module.baseAddress), &featureOptions{
+			Label:  "moduledata",
+			Offset: uint64(module.moduledataOffset),
+		})
+	}
+
+	summary := &goFileSummary{goVersion: pclntabVersion(module.pclntabData)}
+	classifier := &packageClassifier{prefixes: gi.settings.userCodePrefixes()}
+	buildSettings := map[string]string{}
+	if buildInfo := findMemoryBuildInfo(data, module); buildInfo != nil {
+		summary.goVersion = buildInfo.GoVersion
+		features.report.setBuildInfo(buildInfo)
+		classifier.mainModule = buildInfo.Main.Path
+		for _, dep := range buildInfo.Deps {
+			summary.modules = append(summary.modules, dep.Path+"@"+dep.Version)
+			classifier.dependencies = append(classifier.dependencies, dep.Path)
+		}
+		for _, s := range buildInfo.Settings {
+			buildSettings[s.Key] = s.Value
+			features.addFeatureWithExtra("go_compiler_flag", s.Value, &featureOptions{
+				Label: s.Key,
+			})
+		}
+		features.addFeature("go_compiler_version", buildInfo.GoVersion)
+	}
+	features.report.GoVersion = summary.goVersion
+	if buildID := findGoBuildID(data); buildID != "" {
+		features.report.BuildID = buildID
+		features.addFeature("go_build_id", buildID)
+	}
+
+	addCgoFeatures(features, detectCgo(contentFilePath, buildSettings, module.pclntab))
+	stageCtx, cancelStage := gi.startStage(ctx, analysisStagePackages)
+	defer cancelStage()
+	addPclntabPackageFeatures(stageCtx, features, classifier, module.pclntab)
+
+	// Type names only hold the last element of the package path, so match them against the user packages.
+	userPackages := map[string]struct{}{}
+	for _, pkg := range groupPclntabPackages(pclntabFunctions(module.pclntab)) {
+		if classifier.isUser(pkg.name) && !classifier.isVendor(pkg.name) {
+			userPackages[path.Base(pkg.name)] = struct{}{}
+		}
+	}
+	stageCtx, cancelStage = gi.startStage(ctx, analysisStageTypes)
+	defer cancelStage()
+	for _, goType := range scanMemoryTypes(data, module) {
+		if stageCtx.Err() != nil {
+			addTruncatedStage(features, analysisStageTypes, stageCtx.Err())
+			return summary, nil
+		}
+		packageName, _, _ := strings.Cut(strings.TrimPrefix(goType.name, "*"), ".")
+		if _, ok := userPackages[packageName]; !ok {
+			continue
+		}
+		features.report.addType(&Type{Name: goType.name, Kind: goType.kind, Address: goType.address})
+		features.addFeatureWithExtra("go_type", goType.name, &featureOptions{
+			Label:  goType.kind,
+			Offset: goType.address,
+		})
+	}
+	return summary, nil
+}
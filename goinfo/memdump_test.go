@@ -0,0 +1,180 @@
+package goinfo
+
+import (
+	"context"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const memoryDumpTestSource = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type Config struct {
+	Server string
+	Port   int
+}
+
+type Beacon interface {
+	Send() error
+}
+
+//go:noinline
+func (c *Config) Send() error {
+	_, err := os.Stdout.WriteString(c.Server)
+	return err
+}
+
+func main() {
+	var beacon Beacon = &Config{Server: "example.com", Port: 443}
+	beacon.Send()
+	fmt.Println(beacon)
+}
+`
+
+// Lay out the loadable segments of an ELF file as they would appear in a process memory dump.
+func elfMemoryImage(t *testing.T, binaryPath string) ([]byte, uint64) {
+	elfFile, err := elf.Open(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer elfFile.Close()
+	base, end := ^uint64(0), uint64(0)
+	for _, prog := range elfFile.Progs {
+		if prog.Type == elf.PT_LOAD {
+			base = min(base, prog.Vaddr&^0xfff)
+			end = max(end, prog.Vaddr+prog.Memsz)
+		}
+	}
+	image := make([]byte, end-base)
+	for _, prog := range elfFile.Progs {
+		if prog.Type != elf.PT_LOAD {
+			continue
+		}
+		_, err := prog.ReadAt(image[prog.Vaddr-base:prog.Vaddr-base+prog.Filesz], 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Wipe the ELF header so nothing can rely on it.
+	clear(image[:64])
+	return image, base
+}
+
+func TestFindMemoryModule(t *testing.T) {
+	for _, goarch := range []string{"amd64", "386", "arm64"} {
+		t.Run(goarch, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, memoryDumpTestSource, []string{"GOOS=linux", "GOARCH=" + goarch})
+			image, base := elfMemoryImage(t, binaryPath)
+
+			module := findMemoryModule(image)
+			if module == nil {
+				t.Fatal("no Go module found in the memory image")
+			}
+			if module.moduledataOffset < 0 || module.baseAddress != base {
+				t.Errorf("expected base address 0x%x got 0x%x (moduledata at %d)", base, module.baseAddress, module.moduledataOffset)
+			}
+			var mainFunction *pclntabFunction
+			for _, function := range pclntabFunctions(module.pclntab) {
+				if function.symbol == "main.main" {
+					mainFunction = &function
+				}
+			}
+			if mainFunction == nil || mainFunction.entry < base || mainFunction.entry >= base+uint64(len(image)) {
+				t.Errorf("expected main.main inside the image, got %+v", mainFunction)
+			}
+
+			types := map[string]string{}
+			for _, goType := range scanMemoryTypes(image, module) {
+				types[goType.name] = goType.kind
+			}
+			if types["main.Config"] != "struct" || types["*main.Config"] != "ptr" {
+				t.Errorf("expected main.Config and *main.Config types, got %v", types)
+			}
+
+			buildInfo := findMemoryBuildInfo(image, module)
+			if buildInfo == nil || buildInfo.Main.Path != "example.com/hello" || buildInfo.GoVersion == "" {
+				t.Errorf("expected build info for example.com/hello, got %v", buildInfo)
+			}
+		})
+	}
+}
+
+func TestFindMemoryModuleFragment(t *testing.T) {
+	binaryPath := buildTestProgram(t, memoryDumpTestSource, []string{"GOOS=linux", "GOARCH=amd64"})
+	pclntabData, _, err := locatePclntab(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A carved fragment only holding the pclntab, surrounded by junk.
+	fragment := append(make([]byte, 1000), pclntabData...)
+	fragment = append(fragment, make([]byte, 1000)...)
+
+	module := findMemoryModule(fragment)
+	if module == nil {
+		t.Fatal("no Go module found in the fragment")
+	}
+	if module.pclntabOffset != 1000 || module.moduledataOffset >= 0 {
+		t.Errorf("expected a pclntab at 1000 without a moduledata, got %d %d", module.pclntabOffset, module.moduledataOffset)
+	}
+	found := false
+	for _, pkg := range groupPclntabPackages(pclntabFunctions(module.pclntab)) {
+		if pkg.name == "main" && len(pkg.methods) == 1 && pkg.methods[0].name == "Send" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the main package with the Send method")
+	}
+	if scanMemoryTypes(fragment, module) != nil || findMemoryBuildInfo(fragment, module) != nil {
+		t.Errorf("expected no types or build info without a moduledata")
+	}
+}
+
+func TestFindMemoryModuleNotGo(t *testing.T) {
+	data, err := os.ReadFile("memdump.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findMemoryModule(data) != nil {
+		t.Errorf("expected no Go module in a text file")
+	}
+}
+
+func TestFindMemoryBuildInfoLengthOverflow(t *testing.T) {
+	// Build info with inline strings whose varint length wraps around when added to the offset.
+	inline := make([]byte, 32, 64)
+	copy(inline, goBuildInfoMarker)
+	inline[14], inline[15] = 8, 2
+	inline = binary.AppendUvarint(inline, ^uint64(0)-4)
+	inline = append(inline, "go1.22"...)
+	if findMemoryBuildInfo(inline, nil) != nil {
+		t.Error("expected no build info with an overflowing inline string length")
+	}
+
+	// Build info pointing at a string header whose length wraps around when added to the string's offset.
+	pointers := make([]byte, 64)
+	copy(pointers, goBuildInfoMarker)
+	pointers[14] = 8
+	binary.LittleEndian.PutUint64(pointers[16:], 0x1000+40)
+	binary.LittleEndian.PutUint64(pointers[40:], 0x1000+56)
+	binary.LittleEndian.PutUint64(pointers[48:], ^uint64(0)-16)
+	module := &memoryModule{moduledataOffset: 0, baseAddress: 0x1000}
+	if findMemoryBuildInfo(pointers, module) != nil {
+		t.Error("expected no build info with an overflowing string length")
+	}
+
+	// Carved dumps reach the inline strings through the fallback analysis.
+	filePath := filepath.Join(t.TempDir(), "carved.bin")
+	err := os.WriteFile(filePath, append(make([]byte, 64), inline...), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = NewAnalyzer(nil).Analyze(context.Background(), filePath)
+}
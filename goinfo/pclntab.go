@@ -0,0 +1,508 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/gosym"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/goretk/gore"
+)
+
+// Magic numbers at the start of the pclntab header for each layout version.
+const (
+	pclntabMagic12  = 0xfffffffb
+	pclntabMagic116 = 0xfffffffa
+	pclntabMagic118 = 0xfffffff0
+	pclntabMagic120 = 0xfffffff1
+)
+
+// Marker written by the Go linker in front of the build ID in every Go binary.
+var goBuildIDMarker = []byte("\xff Go build ID: \"")
+
+// A function recovered from the pclntab.
+type pclntabFunction struct {
+	// Full symbol name, e.g 'main.(*Agent).beacon'.
+	symbol      string
+	packageName string
+	// Name without the package and receiver, e.g 'beacon'.
+	name string
+	// Receiver including brackets and pointer, e.g '(*Agent)', empty for functions.
+	receiver string
+	parsed   goSymbol
+	file     string
+	entry    uint64
+	end      uint64
+}
+
+// Get the byte order of a pclntab from its magic number, or nil if the data is not a pclntab header.
+func pclntabByteOrder(data []byte) binary.ByteOrder {
+	if len(data) < 8 {
+		return nil
+	}
+	// Both bytes after the magic are zero, the instruction size quantum is 1, 2 or 4 and pointers are 4 or 8 bytes.
+	if data[4] != 0 || data[5] != 0 || (data[6] != 1 && data[6] != 2 && data[6] != 4) || (data[7] != 4 && data[7] != 8) {
+		return nil
+	}
+	for _, byteOrder := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch byteOrder.Uint32(data) {
+		case pclntabMagic12, pclntabMagic116, pclntabMagic118, pclntabMagic120:
+			return byteOrder
+		}
+	}
+	return nil
+}
+
+// Get the Go version range a pclntab header was written by.
+func pclntabVersion(data []byte) string {
+	byteOrder := pclntabByteOrder(data)
+	if byteOrder == nil {
+		return ""
+	}
+	switch byteOrder.Uint32(data) {
+	case pclntabMagic12:
+		return "go1.2-go1.15"
+	case pclntabMagic116:
+		return "go1.16-go1.17"
+	case pclntabMagic118:
+		return "go1.18-go1.19"
+	default:
+		return "go1.20+"
+	}
+}
+
+// Read the text start address stored in a go1.18+ pclntab header, 0 is returned for older layouts.
+func pclntabTextStart(data []byte) uint64 {
+	byteOrder := pclntabByteOrder(data)
+	if byteOrder == nil {
+		return 0
+	}
+	magic := byteOrder.Uint32(data)
+	if magic != pclntabMagic118 && magic != pclntabMagic120 {
+		return 0
+	}
+	// The header is magic, padding, quantum and pointer size followed by nfunc, nfiles then textStart.
+	ptrSize := int(data[7])
+	offset := 8 + 2*ptrSize
+	if len(data) < offset+ptrSize {
+		return 0
+	}
+	if ptrSize == 4 {
+		return uint64(byteOrder.Uint32(data[offset:]))
+	}
+	return byteOrder.Uint64(data[offset:])
+}
+
+// Only the first few pclntab candidates are returned, so files full of look alike bytes stay fast.
+const maxPclntabCandidates = 64
+
+// Find the first offsets in data that look like the start of a pclntab header.
+func findPclntabCandidates(data []byte) []int {
+	candidates := []int{}
+	// The header is always pointer aligned so only check every 4 bytes.
+	for offset := 0; offset+8 <= len(data) && len(candidates) < maxPclntabCandidates; offset += 4 {
+		if data[offset] != 0xff && data[offset+3] != 0xff {
+			continue
+		}
+		if pclntabByteOrder(data[offset:]) != nil {
+			candidates = append(candidates, offset)
+		}
+	}
+	return candidates
+}
+
+// Check the function count in a pclntab header fits in the table.
+// Gosym allocates the function table from the count in the header before reading it, so a crafted count
+// can exhaust memory. Gosym only reads the low 32 bits of the count and every function has at least 8 bytes
+// in the function table.
+func checkPclntabFunctionCount(data []byte, byteOrder binary.ByteOrder) error {
+	functionCount := uint64(uint32(readMemoryWord(data, 8, int(data[7]), byteOrder)))
+	if functionCount > uint64(len(data)/8) {
+		return fmt.Errorf("pclntab has %d functions which don't fit in %d bytes", functionCount, len(data))
+	}
+	return nil
+}
+
+// Check that gore can build the pclntab it finds without running out of memory, which can't be recovered from.
+func checkGorePclntab(goFile *gore.GoFile, contentFilePath string) error {
+	data, err := gorePclntab(goFile, contentFilePath)
+	if err != nil {
+		return err
+	}
+	byteOrder := pclntabByteOrder(data)
+	if byteOrder == nil {
+		// Gosym doesn't read the function table without a header it recognises.
+		return nil
+	}
+	return checkPclntabFunctionCount(data, byteOrder)
+}
+
+// Find the pclntab the way gore does, nil if gore won't find one.
+// Gore reads the pclntab between the runtime.pclntab and runtime.epclntab symbols when the file has them and
+// otherwise reads the pclntab section, or searches the sections the linker puts it in for the last header.
+func gorePclntab(goFile *gore.GoFile, contentFilePath string) ([]byte, error) {
+	if data := gorePclntabSymbolRange(goFile); data != nil {
+		return data, nil
+	}
+	fileData, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return nil, err
+	}
+	reader := bytes.NewReader(fileData)
+	switch {
+	case bytes.HasPrefix(fileData, []byte(elf.ELFMAG)):
+		elfFile, err := elf.NewFile(reader)
+		if err != nil {
+			return nil, nil
+		}
+		for _, name := range []string{".gopclntab", ".data.rel.ro.gopclntab"} {
+			if section := elfFile.Section(name); section != nil {
+				data, _ := section.Data()
+				return data, nil
+			}
+		}
+		// Externally linked binaries put the pclntab in .data.rel.ro.
+		if section := elfFile.Section(".data.rel.ro"); section != nil {
+			data, err := section.Data()
+			if err == nil {
+				return searchGorePclntab(data, elfFile.ByteOrder), nil
+			}
+		}
+	case bytes.HasPrefix(fileData, []byte("MZ")):
+		peFile, err := pe.NewFile(reader)
+		if err != nil {
+			return nil, nil
+		}
+		for _, name := range []string{".rdata", ".text"} {
+			section := peFile.Section(name)
+			if section == nil {
+				continue
+			}
+			data, err := section.Data()
+			if err != nil {
+				continue
+			}
+			if table := searchGorePclntab(data, binary.LittleEndian); table != nil {
+				return table, nil
+			}
+		}
+	default:
+		machoFile, err := macho.NewFile(reader)
+		if err != nil {
+			return nil, nil
+		}
+		if section := machoFile.Section("__gopclntab"); section != nil {
+			data, _ := section.Data()
+			return data, nil
+		}
+	}
+	return nil, nil
+}
+
+// Search a section for the last pclntab header the way gore does, trying the newest magic first.
+// Like gore a header at the very start of the section isn't found by the search.
+func searchGorePclntab(data []byte, byteOrder binary.ByteOrder) []byte {
+	for _, magic := range []uint32{pclntabMagic120, pclntabMagic118, pclntabMagic116, pclntabMagic12} {
+		// The magic is followed by two zero bytes.
+		pattern := make([]byte, 6)
+		byteOrder.PutUint32(pattern, magic)
+		for offset := bytes.LastIndex(data, pattern); offset > 0; offset = bytes.LastIndex(data[:offset-1], pattern) {
+			header := data[offset:]
+			if len(header) >= 16 && (header[6] == 1 || header[6] == 2 || header[6] == 4) && (header[7] == 4 || header[7] == 8) {
+				return header
+			}
+		}
+	}
+	return nil
+}
+
+// Read the pclntab between the runtime.pclntab and runtime.epclntab symbols the way gore does, nil if it can't be read.
+func gorePclntabSymbolRange(goFile *gore.GoFile) []byte {
+	start, err := goFile.GetSymbol("runtime.pclntab")
+	if err != nil {
+		return nil
+	}
+	end, err := goFile.GetSymbol("runtime.epclntab")
+	if err != nil || end.Value < start.Value {
+		return nil
+	}
+	data, err := goFile.Bytes(start.Value, end.Value-start.Value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Parse the pclntab, recovering from any panic caused by corrupted tables.
+func parsePclntab(data []byte, textStart uint64) (table *gosym.Table, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			table = nil
+			err = fmt.Errorf("pclntab parsing panicked: %v", recovered)
+		}
+	}()
+	byteOrder := pclntabByteOrder(data)
+	if byteOrder == nil {
+		return nil, errors.New("no pclntab magic found")
+	}
+	if err := checkPclntabFunctionCount(data, byteOrder); err != nil {
+		return nil, err
+	}
+	if textStart == 0 {
+		textStart = pclntabTextStart(data)
+	}
+	table, err = gosym.NewTable(nil, gosym.NewLineTable(data, textStart))
+	if err != nil {
+		return nil, err
+	}
+	if len(table.Funcs) == 0 {
+		return nil, errors.New("pclntab has no functions")
+	}
+	return table, nil
+}
+
+// List the functions in a parsed pclntab with their source files.
+func pclntabFunctions(table *gosym.Table) []pclntabFunction {
+	functions := []pclntabFunction{}
+	for _, function := range table.Funcs {
+		fileName, _, _ := table.PCToLine(function.Entry)
+		parsed := parseGoSymbol(function.Name)
+		functions = append(functions, pclntabFunction{
+			symbol:      function.Name,
+			packageName: parsed.packagePath,
+			name:        parsed.functionName(),
+			receiver:    parsed.receiverName(),
+			parsed:      parsed,
+			file:        fileName,
+			entry:       function.Entry,
+			end:         function.End,
+		})
+	}
+	return functions
+}
+
+// Read the text section start and the pclntab from an executable using its section headers or symbols.
+// The whole file is scanned for a pclntab if it can't be found from the headers.
+func locatePclntab(filePath string) ([]byte, uint64, error) {
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	reader := bytes.NewReader(fileData)
+
+	var pclntabData []byte
+	var textStart uint64
+	switch {
+	case bytes.HasPrefix(fileData, []byte(elf.ELFMAG)):
+		elfFile, err := elf.NewFile(reader)
+		if err != nil {
+			break
+		}
+		if text := elfFile.Section(".text"); text != nil {
+			textStart = text.Addr
+		}
+		if section := elfFile.Section(".gopclntab"); section != nil {
+			pclntabData, _ = section.Data()
+		}
+		if pclntabData == nil {
+			// Externally linked PIE binaries move the pclntab into .data.rel.ro, so find it with its symbols.
+			symbols, _ := elfFile.Symbols()
+			pclntabData = elfSymbolRange(elfFile, symbols, "runtime.pclntab", "runtime.epclntab")
+		}
+	case bytes.HasPrefix(fileData, []byte("MZ")):
+		peFile, err := pe.NewFile(reader)
+		if err != nil {
+			break
+		}
+		imageBase := uint64(0)
+		switch oh := peFile.OptionalHeader.(type) {
+		case *pe.OptionalHeader32:
+			imageBase = uint64(oh.ImageBase)
+		case *pe.OptionalHeader64:
+			imageBase = oh.ImageBase
+		}
+		if text := peFile.Section(".text"); text != nil {
+			textStart = imageBase + uint64(text.VirtualAddress)
+		}
+		pclntabData = peSymbolRange(peFile, "runtime.pclntab", "runtime.epclntab")
+	default:
+		machoFile, err := macho.NewFile(reader)
+		if err != nil {
+			break
+		}
+		if text := machoFile.Section("__text"); text != nil {
+			textStart = text.Addr
+		}
+		if section := machoFile.Section("__gopclntab"); section != nil {
+			pclntabData, _ = section.Data()
+		}
+	}
+
+	if pclntabByteOrder(pclntabData) == nil {
+		pclntabData = nil
+		candidates := findPclntabCandidates(fileData)
+		var moduledatas []moduledataLocation
+		if textStart == 0 {
+			// Without usable headers the moduledata pointing at the pclntab still holds the text address.
+			moduledatas = findModuledatas(fileData, candidates)
+		}
+		for i, candidate := range candidates {
+			candidateTextStart := textStart
+			if candidateTextStart == 0 {
+				candidateTextStart, _ = moduledataTextRange(fileData, candidate, moduledatas[i].offset)
+			}
+			if _, err := parsePclntab(fileData[candidate:], candidateTextStart); err == nil {
+				pclntabData = fileData[candidate:]
+				textStart = candidateTextStart
+				break
+			}
+		}
+	}
+	if pclntabData == nil {
+		return nil, 0, errors.New("no pclntab found")
+	}
+	return pclntabData, textStart, nil
+}
+
+// Read the bytes between two ELF symbols.
+func elfSymbolRange(elfFile *elf.File, symbols []elf.Symbol, startName string, endName string) []byte {
+	var start, end *elf.Symbol
+	for i := range symbols {
+		switch symbols[i].Name {
+		case startName:
+			start = &symbols[i]
+		case endName:
+			end = &symbols[i]
+		}
+	}
+	if start == nil || end == nil || end.Value <= start.Value || int(start.Section) >= len(elfFile.Sections) {
+		return nil
+	}
+	section := elfFile.Sections[start.Section]
+	data, err := section.Data()
+	if err != nil || start.Value < section.Addr || end.Value-section.Addr > uint64(len(data)) {
+		return nil
+	}
+	return data[start.Value-section.Addr : end.Value-section.Addr]
+}
+
+// Read the bytes between two PE COFF symbols, which are section relative.
+func peSymbolRange(peFile *pe.File, startName string, endName string) []byte {
+	var start, end *pe.Symbol
+	for _, symbol := range peFile.Symbols {
+		switch symbol.Name {
+		case startName:
+			start = symbol
+		case endName:
+			end = symbol
+		}
+	}
+	if start == nil || end == nil || start.SectionNumber != end.SectionNumber || start.SectionNumber < 1 || int(start.SectionNumber) > len(peFile.Sections) || end.Value <= start.Value {
+		return nil
+	}
+	data, err := peFile.Sections[start.SectionNumber-1].Data()
+	if err != nil || uint64(end.Value) > uint64(len(data)) {
+		return nil
+	}
+	return data[start.Value:end.Value]
+}
+
+// Find the Go build ID in the ELF note or written by the linker anywhere in the data.
+func findGoBuildID(data []byte) string {
+	if bytes.HasPrefix(data, []byte(elf.ELFMAG)) {
+		if buildID := elfNoteGoBuildID(data); buildID != "" {
+			return buildID
+		}
+	}
+	start := bytes.Index(data, goBuildIDMarker)
+	if start < 0 {
+		return ""
+	}
+	start += len(goBuildIDMarker)
+	end := bytes.IndexByte(data[start:], '"')
+	// Build IDs are at most 4 hashes, anything longer is not a real build ID.
+	if end < 0 || end > 256 {
+		return ""
+	}
+	return string(data[start : start+end])
+}
+
+// Read the Go build ID from the .note.go.buildid section of an ELF file.
+func elfNoteGoBuildID(data []byte) string {
+	elfFile, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	section := elfFile.Section(".note.go.buildid")
+	if section == nil {
+		return ""
+	}
+	note, err := section.Data()
+	// The note is the name size, description size and type followed by the name 'Go\x00\x00' and the build ID.
+	if err != nil || len(note) < 16 {
+		return ""
+	}
+	nameSize := elfFile.ByteOrder.Uint32(note[0:])
+	descriptionSize := elfFile.ByteOrder.Uint32(note[4:])
+	descriptionStart := 12 + uint64((nameSize+3)&^3)
+	if nameSize != 4 || descriptionStart+uint64(descriptionSize) > uint64(len(note)) {
+		return ""
+	}
+	return string(note[descriptionStart : descriptionStart+uint64(descriptionSize)])
+}
+
+// Returns true if the package path looks like it belongs to the Go standard library.
+// Standard library paths never have a dot in their first element.
+func isStandardPackagePath(packagePath string) bool {
+	if packagePath == "main" || packagePath == "" {
+		return false
+	}
+	firstElement, _, _ := strings.Cut(packagePath, "/")
+	return !strings.Contains(firstElement, ".")
+}
+
+// A package recovered from pclntab function names.
+type pclntabPackage struct {
+	name      string
+	directory string
+	functions []pclntabFunction
+	methods   []pclntabFunction
+}
+
+// Group pclntab functions into packages, sorted by name.
+func groupPclntabPackages(functions []pclntabFunction) []*pclntabPackage {
+	packages := map[string]*pclntabPackage{}
+	for _, function := range functions {
+		// Compiler generated symbols such as 'type:.eq.main.T' don't belong to a package.
+		if function.packageName == "" || strings.HasPrefix(function.packageName, "type:") || strings.HasPrefix(function.packageName, "go:") || strings.HasPrefix(function.packageName, "type.") || strings.HasPrefix(function.packageName, "go.") {
+			continue
+		}
+		pkg, ok := packages[function.packageName]
+		if !ok {
+			pkg = &pclntabPackage{name: function.packageName}
+			packages[function.packageName] = pkg
+		}
+		if pkg.directory == "" && function.file != "" && !strings.HasPrefix(function.file, "<autogenerated>") {
+			pkg.directory = path.Dir(strings.ReplaceAll(function.file, "\\", "/"))
+		}
+		if function.receiver != "" {
+			pkg.methods = append(pkg.methods, function)
+		} else {
+			pkg.functions = append(pkg.functions, function)
+		}
+	}
+	packageList := []*pclntabPackage{}
+	for _, pkg := range packages {
+		packageList = append(packageList, pkg)
+	}
+	sort.Slice(packageList, func(i, j int) bool { return packageList[i].name < packageList[j].name })
+	return packageList
+}
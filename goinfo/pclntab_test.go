@@ -0,0 +1,232 @@
+package goinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goretk/gore"
+)
+
+const methodTestSource = `package main
+
+type Agent struct {
+	name string
+}
+
+//go:noinline
+func (a *Agent) beacon() string {
+	return a.name
+}
+
+func main() {
+	a := &Agent{name: "test"}
+	println(a.beacon())
+}
+`
+
+func TestLocatePclntabArchitectures(t *testing.T) {
+	testCases := []struct {
+		goos   string
+		goarch string
+	}{
+		{"linux", "mips"},
+		{"linux", "mipsle"},
+		{"linux", "mips64"},
+		{"linux", "ppc64"},
+		{"linux", "ppc64le"},
+		{"linux", "s390x"},
+		{"linux", "loong64"},
+		{"linux", "riscv64"},
+		{"windows", "arm64"},
+		{"darwin", "arm64"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.goos+"_"+tc.goarch, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, methodTestSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch})
+			pclntabData, textStart, err := locatePclntab(binaryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table, err := parsePclntab(pclntabData, textStart)
+			if err != nil {
+				t.Fatal(err)
+			}
+			packages := groupPclntabPackages(pclntabFunctions(table))
+			var mainPackage *pclntabPackage
+			for _, pkg := range packages {
+				if pkg.name == "main" {
+					mainPackage = pkg
+				}
+			}
+			if mainPackage == nil {
+				t.Fatalf("main package not found")
+			}
+			if len(mainPackage.methods) != 1 || mainPackage.methods[0].name != "beacon" || mainPackage.methods[0].receiver != "(*Agent)" {
+				t.Errorf("expected method beacon on (*Agent), got %v", mainPackage.methods)
+			}
+			foundMain := false
+			for _, function := range mainPackage.functions {
+				if function.name == "main" {
+					foundMain = true
+					if function.entry < textStart || function.end <= function.entry {
+						t.Errorf("invalid function range %x-%x", function.entry, function.end)
+					}
+				}
+			}
+			if !foundMain {
+				t.Errorf("expected main.main in %v", mainPackage.functions)
+			}
+
+			fileData, err := os.ReadFile(binaryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if findGoBuildID(fileData) == "" {
+				t.Errorf("expected a Go build ID")
+			}
+		})
+	}
+}
+
+func TestFindPclntabCandidates(t *testing.T) {
+	data := make([]byte, 64)
+	// A little endian go1.20 header at offset 16, followed by a big endian go1.16 header at offset 32.
+	copy(data[16:], []byte{0xf1, 0xff, 0xff, 0xff, 0, 0, 1, 8})
+	copy(data[32:], []byte{0xff, 0xff, 0xff, 0xfa, 0, 0, 4, 4})
+	// Invalid pointer size.
+	copy(data[48:], []byte{0xf1, 0xff, 0xff, 0xff, 0, 0, 1, 3})
+	candidates := findPclntabCandidates(data)
+	if len(candidates) != 2 || candidates[0] != 16 || candidates[1] != 32 {
+		t.Errorf("unexpected candidates %v", candidates)
+	}
+	if pclntabVersion(data[16:]) != "go1.20+" || pclntabVersion(data[32:]) != "go1.16-go1.17" {
+		t.Errorf("unexpected versions %s %s", pclntabVersion(data[16:]), pclntabVersion(data[32:]))
+	}
+}
+
+func TestLocatePclntabManyCandidates(t *testing.T) {
+	// A file that is nothing but pclntab headers, which used to take a full scan and a parse per header.
+	data := bytes.Repeat([]byte{0xf1, 0xff, 0xff, 0xff, 0, 0, 1, 8}, 1<<19)
+	if candidates := findPclntabCandidates(data); len(candidates) != maxPclntabCandidates {
+		t.Errorf("expected %d candidates got %d", maxPclntabCandidates, len(candidates))
+	}
+	filePath := filepath.Join(t.TempDir(), "headers.bin")
+	err := os.WriteFile(filePath, data, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = locatePclntab(filePath)
+	if err == nil {
+		t.Error("expected no pclntab in a file of headers")
+	}
+}
+
+func TestCheckPclntabFunctionCount(t *testing.T) {
+	// A go1.20 header claiming 0x7fffffff functions made gosym try to allocate hundreds of gigabytes.
+	data := make([]byte, 72)
+	copy(data, []byte{0xf1, 0xff, 0xff, 0xff, 0, 0, 1, 8})
+	for _, tc := range []struct {
+		count uint64
+		fits  bool
+	}{
+		{count: 9, fits: true},
+		{count: 10, fits: false},
+		{count: 0x7fffffff, fits: false},
+		// Gosym only reads the low 32 bits of the count.
+		{count: 1 << 32, fits: true},
+	} {
+		binary.LittleEndian.PutUint64(data[8:], tc.count)
+		err := checkPclntabFunctionCount(data, binary.LittleEndian)
+		if (err == nil) != tc.fits {
+			t.Errorf("count %#x: expected fits %v got %v", tc.count, tc.fits, err)
+		}
+	}
+}
+
+func TestCheckGorePclntab(t *testing.T) {
+	for _, tc := range []struct {
+		goos   string
+		goarch string
+	}{
+		{"linux", "amd64"},
+		{"windows", "amd64"},
+		{"darwin", "arm64"},
+	} {
+		t.Run(tc.goos+"_"+tc.goarch, func(t *testing.T) {
+			// Stripped so gore has to find the pclntab without its symbols.
+			binaryPath := buildTestProgram(t, methodTestSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch}, "-ldflags=-s -w")
+			original, err := os.ReadFile(binaryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pclntabData, _, err := locatePclntab(binaryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pclntabOffset := bytes.Index(original, pclntabData[:64])
+			badHeader := make([]byte, 64)
+			copy(badHeader, []byte{0xf1, 0xff, 0xff, 0xff, 0, 0, 1, 8})
+			binary.LittleEndian.PutUint64(badHeader[8:], 0x7fffffff)
+
+			check := func(data []byte) error {
+				inputPath := filepath.Join(t.TempDir(), "input")
+				if err := os.WriteFile(inputPath, data, 0o600); err != nil {
+					t.Fatal(err)
+				}
+				goFile, err := gore.Open(inputPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer goFile.Close()
+				return checkGorePclntab(goFile, inputPath)
+			}
+			if err := check(original); err != nil {
+				t.Errorf("expected the pclntab to fit, got %v", err)
+			}
+			// A bad header outside the sections gore reads the pclntab from doesn't matter.
+			if err := check(append(bytes.Clone(original), badHeader...)); err != nil {
+				t.Errorf("expected a bad header gore doesn't read to be ignored, got %v", err)
+			}
+			corrupted := bytes.Clone(original)
+			binary.LittleEndian.PutUint64(corrupted[pclntabOffset+8:], 0x7fffffff)
+			if err := check(corrupted); err == nil {
+				t.Errorf("expected the function count in the pclntab gore reads to be rejected")
+			}
+		})
+	}
+}
+
+func TestPackageClassifier(t *testing.T) {
+	classifier := &packageClassifier{
+		mainModule:   "github.com/evil/implant",
+		dependencies: []string{"github.com/spf13/cobra", "golang.org/x/sys"},
+	}
+	for packagePath, expected := range map[string]string{
+		"main":                                   "user",
+		"github.com/evil/implant/beacon":         "user",
+		"github.com/spf13/cobra":                 "vendor",
+		"golang.org/x/sys/unix":                  "vendor",
+		"net/http":                               "std",
+		"runtime":                                "std",
+		"vendor/golang.org/x/net/dns/dnsmessage": "std",
+	} {
+		actual := "std"
+		if classifier.isVendor(packagePath) {
+			actual = "vendor"
+		} else if classifier.isUser(packagePath) {
+			actual = "user"
+		}
+		if actual != expected {
+			t.Errorf("expected %s to be %s got %s", packagePath, expected, actual)
+		}
+	}
+
+	// Modules without a domain look like the standard library, so rely on the main module path.
+	classifier = &packageClassifier{mainModule: "botnet"}
+	if !classifier.isUser("botnet/scanner") || classifier.isUser("bufio") {
+		t.Errorf("expected only botnet/scanner to be user code")
+	}
+}
@@ -0,0 +1,66 @@
+package goinfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Remove the COFF symbol table from a PE file in place.
+// Long section names are offsets into the string table that follows the symbols, so they are replaced
+// with a placeholder name. The Go metadata doesn't rely on either table so it can still be read afterwards.
+func removePESymbolTable(data []byte) error {
+	if len(data) < 64 || data[0] != 'M' || data[1] != 'Z' {
+		return errors.New("not a PE file")
+	}
+	peHeaderOffset := int(binary.LittleEndian.Uint32(data[0x3c:]))
+	if peHeaderOffset < 0 || peHeaderOffset+24 > len(data) || string(data[peHeaderOffset:peHeaderOffset+4]) != "PE\x00\x00" {
+		return errors.New("PE header not found")
+	}
+	// The COFF header follows the signature, the symbol table pointer and count are at offsets 8 and 12.
+	coffHeader := data[peHeaderOffset+4:]
+	binary.LittleEndian.PutUint32(coffHeader[8:], 0)
+	binary.LittleEndian.PutUint32(coffHeader[12:], 0)
+
+	sectionCount := int(binary.LittleEndian.Uint16(coffHeader[2:]))
+	optionalHeaderSize := int(binary.LittleEndian.Uint16(coffHeader[16:]))
+	sectionHeadersStart := peHeaderOffset + 24 + optionalHeaderSize
+	if sectionHeadersStart+sectionCount*peSectionHeaderSize > len(data) {
+		return errors.New("PE section headers extend past the end of the file")
+	}
+	for i := 0; i < sectionCount; i++ {
+		sectionHeaderOffset := sectionHeadersStart + i*peSectionHeaderSize
+		if data[sectionHeaderOffset] == '/' {
+			name := make([]byte, 8)
+			copy(name, fmt.Sprintf(".sect%d", i))
+			copy(data[sectionHeaderOffset:], name)
+		}
+	}
+	return nil
+}
+
+// Write a copy of a PE file with its COFF symbol table removed to a temporary file.
+// Used when the symbol or string table is corrupted, which stops the file being parsed at all.
+// The caller must remove the temporary file.
+func writeRepairedPE(contentFilePath string) (string, error) {
+	data, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return "", err
+	}
+	err = removePESymbolTable(data)
+	if err != nil {
+		return "", err
+	}
+	repairedFile, err := os.CreateTemp("", "goinfo-repaired-pe-")
+	if err != nil {
+		return "", err
+	}
+	defer repairedFile.Close()
+	_, err = repairedFile.Write(data)
+	if err != nil {
+		os.Remove(repairedFile.Name())
+		return "", err
+	}
+	return repairedFile.Name(), nil
+}
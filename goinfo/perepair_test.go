@@ -0,0 +1,62 @@
+package goinfo
+
+import (
+	"debug/buildinfo"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteRepairedPE(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=windows", "GOARCH=386"})
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Point the symbol table past the end of the file, as seen in corrupted samples.
+	peHeaderOffset := binary.LittleEndian.Uint32(data[0x3c:])
+	binary.LittleEndian.PutUint32(data[peHeaderOffset+12:], uint32(len(data)+0x1000))
+	corruptedPath := filepath.Join(t.TempDir(), "corrupted.exe")
+	err = os.WriteFile(corruptedPath, data, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = pe.Open(corruptedPath)
+	if err == nil || !strings.Contains(err.Error(), "fail to read string table length") {
+		t.Fatalf("expected the string table error, got %v", err)
+	}
+
+	repairedPath, err := writeRepairedPE(corruptedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(repairedPath)
+	peFile, err := pe.Open(repairedPath)
+	if err != nil {
+		t.Fatalf("repaired file could not be parsed: %v", err)
+	}
+	defer peFile.Close()
+	if peFile.Section(".text") == nil {
+		t.Errorf("expected the .text section to be kept")
+	}
+	for _, section := range peFile.Sections {
+		if strings.HasPrefix(section.Name, "/") {
+			t.Errorf("expected long section names to be replaced, got %s", section.Name)
+		}
+	}
+	buildInfo, err := buildinfo.ReadFile(repairedPath)
+	if err != nil || buildInfo.Path != "example.com/hello" {
+		t.Errorf("expected the build info to be readable, got %v %v", buildInfo, err)
+	}
+	if _, _, err := locatePclntab(repairedPath); err != nil {
+		t.Errorf("expected the pclntab to be found: %v", err)
+	}
+
+	_, err = writeRepairedPE("perepair.go")
+	if err == nil {
+		t.Errorf("expected an error repairing a file that isn't a PE")
+	}
+}
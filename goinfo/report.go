@@ -0,0 +1,203 @@
+package goinfo
+
+import (
+	"runtime/debug"
+
+	"github.com/goretk/gore"
+)
+
+// Version of the JSON report layout, bumped whenever a field is removed or changes meaning.
+const reportVersion = 1
+
+// Structured analysis of a single Go binary, which the plugin attaches to the entity as a JSON stream.
+// Unlike the features it keeps the hierarchy, e.g. which methods belong to which type.
+type BinaryReport struct {
+	ReportVersion int `json:"report_version"`
+	// Architecture of the slice for universal Mach-O files.
+	Architecture string `json:"architecture,omitempty"`
+	GoVersion    string `json:"go_version,omitempty"`
+	BuildID      string `json:"build_id,omitempty"`
+	// How the metadata was found when gore couldn't be used.
+	Fallback  string            `json:"fallback,omitempty"`
+	Module    *Module           `json:"module,omitempty"`
+	BuildMode string            `json:"build_mode,omitempty"`
+	LinkMode  string            `json:"link_mode,omitempty"`
+	Settings  map[string]string `json:"build_settings,omitempty"`
+	Packages  []*Package        `json:"packages"`
+	Types     []*Type           `json:"types"`
+	Generics  []Generic         `json:"generics,omitempty"`
+	Warnings  []Warning         `json:"warnings"`
+}
+
+type Module struct {
+	Path         string   `json:"path"`
+	Version      string   `json:"version,omitempty"`
+	Dependencies []Module `json:"dependencies,omitempty"`
+	Replace      *Module  `json:"replace,omitempty"`
+}
+
+type Package struct {
+	Name string `json:"name"`
+	// Either user or library.
+	Kind      string     `json:"kind"`
+	Directory string     `json:"directory,omitempty"`
+	Files     []string   `json:"files,omitempty"`
+	Functions []Function `json:"functions,omitempty"`
+	Methods   []Function `json:"methods,omitempty"`
+}
+
+// A function or method with the address range of its code.
+type Function struct {
+	Name     string `json:"name"`
+	Receiver string `json:"receiver,omitempty"`
+	Start    uint64 `json:"start"`
+	End      uint64 `json:"end"`
+}
+
+type Type struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Package string   `json:"package,omitempty"`
+	Address uint64   `json:"address"`
+	Size    uint64   `json:"size,omitempty"`
+	Fields  []Field  `json:"fields,omitempty"`
+	Methods []string `json:"methods,omitempty"`
+}
+
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Tag      string `json:"tag,omitempty"`
+	Embedded bool   `json:"embedded,omitempty"`
+}
+
+// A generic function or method with the type arguments of each of its instantiations.
+type Generic struct {
+	Name           string   `json:"name"`
+	Instantiations []string `json:"instantiations"`
+}
+
+// A problem found during the analysis, the same problems are reported in the malformed and go_analysis_* features.
+type Warning struct {
+	// One of malformed, analysis_error or truncated.
+	Kind string `json:"kind"`
+	// Anomaly category or the stage that failed.
+	Detail  string `json:"detail,omitempty"`
+	Message string `json:"message"`
+}
+
+const (
+	packageKindUser    = "user"
+	packageKindLibrary = "library"
+)
+
+func newBinaryReport(architecture string) *BinaryReport {
+	return &BinaryReport{
+		ReportVersion: reportVersion,
+		Architecture:  architecture,
+		Packages:      []*Package{},
+		Types:         []*Type{},
+		Warnings:      []Warning{},
+	}
+}
+
+func newReportModule(module *debug.Module) *Module {
+	reported := &Module{Path: module.Path, Version: module.Version}
+	if module.Replace != nil {
+		reported.Replace = newReportModule(module.Replace)
+	}
+	return reported
+}
+
+// Record the build info read from the binary.
+func (r *BinaryReport) setBuildInfo(buildInfo *debug.BuildInfo) {
+	if r == nil {
+		return
+	}
+	r.GoVersion = buildInfo.GoVersion
+	r.Module = newReportModule(&buildInfo.Main)
+	for _, dep := range buildInfo.Deps {
+		r.Module.Dependencies = append(r.Module.Dependencies, *newReportModule(dep))
+	}
+	r.Settings = map[string]string{}
+	for _, s := range buildInfo.Settings {
+		r.Settings[s.Key] = s.Value
+	}
+}
+
+// Add a package to the report and return it so its functions can be added.
+func (r *BinaryReport) addPackage(name string, kind string, directory string) *Package {
+	pkg := &Package{Name: name, Kind: kind, Directory: directory}
+	if r != nil {
+		r.Packages = append(r.Packages, pkg)
+	}
+	return pkg
+}
+
+// Convert a type found by gore, keeping its fields and methods.
+func newReportType(goType *gore.GoType) *Type {
+	reported := &Type{
+		Name:    goType.Name,
+		Kind:    goType.Kind.String(),
+		Package: goType.PackagePath,
+		Address: goType.Addr,
+		Size:    uint64(goType.Length),
+	}
+	for _, field := range goType.Fields {
+		reported.Fields = append(reported.Fields, Field{Name: field.FieldName, Type: field.Name, Tag: field.FieldTag, Embedded: field.FieldAnon})
+	}
+	for _, method := range goType.Methods {
+		reported.Methods = append(reported.Methods, method.Name)
+	}
+	return reported
+}
+
+// Add the functions, methods and source files of a package found in the pclntab.
+func (p *Package) addPclntabFunctions(pkg *pclntabPackage) {
+	files := map[string]struct{}{}
+	addFile := func(file string) {
+		if _, ok := files[file]; !ok && file != "" {
+			files[file] = struct{}{}
+			p.Files = append(p.Files, file)
+		}
+	}
+	for _, function := range pkg.functions {
+		p.Functions = append(p.Functions, Function{Name: function.name, Start: function.entry, End: function.end})
+		addFile(function.file)
+	}
+	for _, method := range pkg.methods {
+		p.Methods = append(p.Methods, Function{Name: method.name, Receiver: method.receiver, Start: method.entry, End: method.end})
+		addFile(method.file)
+	}
+}
+
+func (r *BinaryReport) addType(reported *Type) {
+	if r != nil {
+		r.Types = append(r.Types, reported)
+	}
+}
+
+func (r *BinaryReport) addGeneric(name string, instantiations []string) {
+	if r != nil {
+		r.Generics = append(r.Generics, Generic{Name: name, Instantiations: instantiations})
+	}
+}
+
+func (r *BinaryReport) setFallback(reason string) {
+	if r != nil {
+		r.Fallback = reason
+	}
+}
+
+func (r *BinaryReport) addWarning(kind string, detail string, message string) {
+	if r != nil {
+		r.Warnings = append(r.Warnings, Warning{Kind: kind, Detail: detail, Message: message})
+	}
+}
+
+// Add the binary report to the result of the analysis.
+func (fw *featureWriter) writeReport() {
+	if fw.report != nil {
+		fw.result.Binaries = append(fw.result.Binaries, fw.report)
+	}
+}
@@ -0,0 +1,86 @@
+package goinfo
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime/debug"
+	"testing"
+
+	"github.com/goretk/gore"
+)
+
+func TestAnalysisReportJSON(t *testing.T) {
+	report := newBinaryReport("arm64")
+	report.setBuildInfo(&debug.BuildInfo{
+		GoVersion: "go1.22.1",
+		Main:      debug.Module{Path: "example.com/implant", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: "github.com/spf13/cobra", Version: "v1.8.0", Replace: &debug.Module{Path: "github.com/evil/cobra", Version: "v0.0.1"}},
+		},
+		Settings: []debug.BuildSetting{{Key: "-trimpath", Value: "true"}},
+	})
+	report.addPackage("main", packageKindUser, "/src/implant").addPclntabFunctions(&pclntabPackage{
+		name: "main",
+		functions: []pclntabFunction{
+			{name: "main", file: "/src/implant/main.go", entry: 0x1000, end: 0x1080},
+		},
+		methods: []pclntabFunction{
+			{name: "Send", receiver: "(*Config)", file: "/src/implant/config.go", entry: 0x1080, end: 0x10c0},
+			{name: "String", receiver: "Config", file: "/src/implant/config.go", entry: 0x10c0, end: 0x10d0},
+		},
+	})
+	report.addType(newReportType(&gore.GoType{
+		Name:        "main.Config",
+		Kind:        reflect.Struct,
+		PackagePath: "main",
+		Addr:        0x2000,
+		Fields: []*gore.GoType{
+			{Name: "string", FieldName: "Server", FieldTag: `json:"server"`},
+			{Name: "main.Base", FieldName: "Base", FieldAnon: true},
+		},
+		Methods: []*gore.TypeMethod{{Name: "Send"}},
+	}))
+	report.addWarning("truncated", analysisStageTypes, "time_budget")
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := map[string]any{}
+	err = json.Unmarshal(reportJSON, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded["report_version"] != float64(reportVersion) || decoded["architecture"] != "arm64" || decoded["go_version"] != "go1.22.1" {
+		t.Errorf("unexpected report header %s", reportJSON)
+	}
+	module := decoded["module"].(map[string]any)
+	dependency := module["dependencies"].([]any)[0].(map[string]any)
+	if module["path"] != "example.com/implant" || dependency["replace"].(map[string]any)["path"] != "github.com/evil/cobra" {
+		t.Errorf("unexpected module %v", module)
+	}
+
+	pkg := report.Packages[0]
+	if len(pkg.Functions) != 1 || len(pkg.Methods) != 2 || pkg.Methods[0].Receiver != "(*Config)" || pkg.Methods[0].End != 0x10c0 {
+		t.Errorf("unexpected package functions %+v", pkg)
+	}
+	if !reflect.DeepEqual(pkg.Files, []string{"/src/implant/main.go", "/src/implant/config.go"}) {
+		t.Errorf("unexpected package files %v", pkg.Files)
+	}
+	goType := report.Types[0]
+	if goType.Kind != "struct" || len(goType.Fields) != 2 || !goType.Fields[1].Embedded || goType.Fields[0].Tag != `json:"server"` || goType.Methods[0] != "Send" {
+		t.Errorf("unexpected type %+v", goType)
+	}
+	if report.Warnings[0].Kind != "truncated" || report.Warnings[0].Detail != analysisStageTypes {
+		t.Errorf("unexpected warnings %v", report.Warnings)
+	}
+
+	// Empty sections are still present so consumers don't need to check for them.
+	reportJSON, err = json.Marshal(newBinaryReport(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(reportJSON) != `{"report_version":1,"packages":[],"types":[],"warnings":[]}` {
+		t.Errorf("unexpected empty report %s", reportJSON)
+	}
+}
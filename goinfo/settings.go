@@ -0,0 +1,303 @@
+package goinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goretk/gore"
+)
+
+// Time budget used for a stage when the settings don't give one.
+const defaultStageBudget = 2 * time.Minute
+
+// Prefix of the environment variables holding the plugin settings, PLUGIN_GOINFO_PROFILE sets goinfo_profile.
+const settingEnvironmentPrefix = "PLUGIN_"
+
+// Plugin setting holding the time budget for every stage, as a Go duration such as "90s".
+const stageBudgetSetting = "goinfo_stage_budget"
+
+// Prefix of the plugin settings overriding the time budget of a single stage, e.g. goinfo_stage_budget_types.
+// A budget of zero removes the limit for the stage.
+const stageBudgetSettingPrefix = stageBudgetSetting + "_"
+
+// Plugin setting holding the maximum number of values kept for every capped feature.
+const featureCapSetting = "goinfo_feature_cap"
+
+// Prefix of the plugin settings overriding the cap of a single feature, e.g. goinfo_feature_cap_go_type.
+// A cap of zero keeps every value.
+const featureCapSettingPrefix = featureCapSetting + "_"
+
+// Plugin setting naming the analysis profile, fast or full.
+const profileSetting = "goinfo_profile"
+
+// Prefix of the plugin settings turning a feature group on or off over the profile, e.g. goinfo_group_types=false.
+const featureGroupSettingPrefix = "goinfo_group_"
+
+// Plugin settings holding comma separated package path prefixes to always treat as user or library code.
+const (
+	userPackagesSetting    = "goinfo_user_packages"
+	libraryPackagesSetting = "goinfo_library_packages"
+)
+
+// Settings shared by every analysis they are used for.
+type Settings struct {
+	defaultStageBudget time.Duration
+	stageBudgets       map[string]time.Duration
+	defaultFeatureCap  int
+	featureCaps        map[string]int
+	featureGroups      map[featureGroup]bool
+	packagePrefixes    *packagePrefixes
+}
+
+// Get the plugin settings with their default values, for registering them with the plugin runtime.
+// The settings overriding a single stage, feature group or feature cap have no default and aren't included.
+func SettingDefaults() map[string]string {
+	return map[string]string{
+		stageBudgetSetting:     defaultStageBudget.String(),
+		featureCapSetting:      strconv.Itoa(defaultFeatureCap),
+		profileSetting:         defaultAnalysisProfile,
+		userPackagesSetting:    "",
+		libraryPackagesSetting: "",
+	}
+}
+
+// Read the plugin settings from environment variables in the form key=value, e.g. PLUGIN_GOINFO_PROFILE=fast.
+func LoadSettings(environ []string) (*Settings, error) {
+	config := map[string]string{}
+	for _, entry := range environ {
+		key, value, _ := strings.Cut(entry, "=")
+		if strings.HasPrefix(key, settingEnvironmentPrefix) {
+			config[strings.ToLower(strings.TrimPrefix(key, settingEnvironmentPrefix))] = value
+		}
+	}
+	return ParseSettings(config)
+}
+
+// Read the plugin settings from a job's plugin config, settings that aren't given keep their defaults.
+// Keys that aren't goinfo settings are ignored.
+func ParseSettings(config map[string]string) (*Settings, error) {
+	settings := &Settings{
+		defaultStageBudget: defaultStageBudget,
+		stageBudgets:       map[string]time.Duration{},
+		defaultFeatureCap:  defaultFeatureCap,
+		featureCaps:        map[string]int{},
+		packagePrefixes:    &packagePrefixes{},
+	}
+	profile := defaultAnalysisProfile
+	groupOverrides := map[featureGroup]bool{}
+	for key, value := range config {
+		if key == profileSetting {
+			profile = value
+			continue
+		}
+		if key == userPackagesSetting {
+			settings.packagePrefixes.user = parsePackagePrefixes(value)
+			continue
+		}
+		if key == libraryPackagesSetting {
+			settings.packagePrefixes.library = parsePackagePrefixes(value)
+			continue
+		}
+		if strings.HasPrefix(key, featureGroupSettingPrefix) {
+			group := featureGroup(strings.TrimPrefix(key, featureGroupSettingPrefix))
+			enabled, err := strconv.ParseBool(value)
+			if !slices.Contains(allFeatureGroups, group) || err != nil {
+				return nil, fmt.Errorf("invalid feature group setting %s=%s", key, value)
+			}
+			groupOverrides[group] = enabled
+			continue
+		}
+		if key == featureCapSetting || strings.HasPrefix(key, featureCapSettingPrefix) {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("invalid feature cap %q for %s", value, key)
+			}
+			if key == featureCapSetting {
+				settings.defaultFeatureCap = limit
+			} else {
+				settings.featureCaps[strings.TrimPrefix(key, featureCapSettingPrefix)] = limit
+			}
+			continue
+		}
+		if key != stageBudgetSetting && !strings.HasPrefix(key, stageBudgetSettingPrefix) {
+			continue
+		}
+		budget, err := time.ParseDuration(value)
+		if err != nil || budget < 0 {
+			return nil, fmt.Errorf("invalid time budget %q for %s", value, key)
+		}
+		if key == stageBudgetSetting {
+			settings.defaultStageBudget = budget
+			continue
+		}
+		settings.stageBudgets[strings.TrimPrefix(key, stageBudgetSettingPrefix)] = budget
+	}
+	// Groups set on their own win over the profile.
+	var err error
+	settings.featureGroups, err = profileFeatureGroups(profile)
+	if err != nil {
+		return nil, err
+	}
+	for group, enabled := range groupOverrides {
+		settings.featureGroups[group] = enabled
+	}
+	return settings, nil
+}
+
+// Check whether a feature is written, features outside the groups always are.
+func (s *Settings) featureEnabled(name string) bool {
+	group, ok := featureGroupMembers[name]
+	if !ok {
+		return true
+	}
+	return s.groupEnabled(group)
+}
+
+// Package path prefixes overriding the user code classification, nil without settings.
+func (s *Settings) userCodePrefixes() *packagePrefixes {
+	if s == nil {
+		return nil
+	}
+	return s.packagePrefixes
+}
+
+// Check whether any feature group needing a stage is turned on, stages no group needs are skipped.
+func (s *Settings) stageEnabled(stage string) bool {
+	groups, ok := analysisStageGroups[stage]
+	if !ok {
+		return true
+	}
+	for _, group := range groups {
+		if s.groupEnabled(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check whether a feature group is turned on, every group is on without settings.
+func (s *Settings) groupEnabled(group featureGroup) bool {
+	if s == nil || s.featureGroups == nil {
+		return true
+	}
+	return s.featureGroups[group]
+}
+
+// Time budget for a stage, zero when the stage is unlimited.
+func (s *Settings) stageBudget(stage string) time.Duration {
+	if s == nil {
+		return defaultStageBudget
+	}
+	if budget, ok := s.stageBudgets[stage]; ok {
+		return budget
+	}
+	return s.defaultStageBudget
+}
+
+// Maximum number of values kept for a capped feature, zero when every value is kept.
+func (s *Settings) featureCap(name string) int {
+	if s == nil {
+		return defaultFeatureCap
+	}
+	if limit, ok := s.featureCaps[name]; ok {
+		return limit
+	}
+	return s.defaultFeatureCap
+}
+
+// Start a stage of the analysis. The returned context is done when the analysis is cancelled or the stage's budget runs out.
+func (gi *Analyzer) startStage(ctx context.Context, stage string) (context.Context, context.CancelFunc) {
+	budget := gi.settings.stageBudget(stage)
+	if budget == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, budget)
+}
+
+// A file opened with gore.
+// Gore calls can't be interrupted, so a call that runs out of time keeps running until it returns.
+// The session keeps the file open until then and refuses later calls, so there is never more than one
+// abandoned call per file and no call reads the file at the same time as another.
+type goreSession struct {
+	goFile          *gore.GoFile
+	contentFilePath string
+	// Calls that haven't returned yet, including abandoned ones.
+	calls sync.WaitGroup
+	// Set once a call is abandoned, the reason later calls are refused.
+	abandoned error
+}
+
+// Close the file once every call has returned.
+// When a call was abandoned the file is closed in the background after it returns.
+func (gs *goreSession) close() {
+	if gs.abandoned == nil {
+		gs.goFile.Close()
+		return
+	}
+	go func() {
+		gs.calls.Wait()
+		gs.goFile.Close()
+	}()
+}
+
+// Run a gore call until it returns or the context is done.
+// A call that is still running when the context is done is abandoned, its result is dropped and every
+// later call on the session fails with the reason it was abandoned.
+func callGoreContext[T any](ctx context.Context, session *goreSession, stage string, call func() (T, error)) (T, error) {
+	var zero T
+	if ctx.Err() != nil {
+		return zero, ctx.Err()
+	}
+	if session.abandoned != nil {
+		return zero, session.abandoned
+	}
+	type goreResult struct {
+		value T
+		err   error
+	}
+	// Buffered so an abandoned call can still send its result and exit.
+	results := make(chan goreResult, 1)
+	session.calls.Add(1)
+	go func() {
+		defer session.calls.Done()
+		value, err := callGore(stage, session.contentFilePath, call)
+		results <- goreResult{value: value, err: err}
+	}()
+	select {
+	case result := <-results:
+		return result.value, result.err
+	case <-ctx.Done():
+		session.abandoned = fmt.Errorf("the %s stage is still running: %w", stage, ctx.Err())
+		return zero, ctx.Err()
+	}
+}
+
+// Check whether an error means a stage was stopped early rather than failing.
+func isStageStopped(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+// Record that a stage stopped before it finished, everything it found up to that point is kept.
+func addTruncatedStage(features *featureWriter, stage string, err error) {
+	reason := "cancelled"
+	if errors.Is(err, context.DeadlineExceeded) {
+		reason = "time_budget"
+	}
+	features.report.addWarning("truncated", stage, reason)
+	features.addFeatureWithExtra("go_analysis_truncated", stage, &featureOptions{Label: reason})
+}
+
+// Record why a stage didn't complete, either it was stopped early or it failed.
+func addStageError(features *featureWriter, stage string, err error) {
+	if isStageStopped(err) {
+		addTruncatedStage(features, stage, err)
+		return
+	}
+	addAnalysisError(features, stage, err)
+}
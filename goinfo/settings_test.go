@@ -0,0 +1,111 @@
+package goinfo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoadGoInfoSettings(t *testing.T) {
+	settings, err := LoadSettings([]string{
+		"PATH=/usr/bin",
+		"PLUGIN_GOINFO_STAGE_BUDGET=30s",
+		"PLUGIN_GOINFO_STAGE_BUDGET_TYPES=5m",
+		"PLUGIN_GOINFO_STAGE_BUDGET_PCLNTAB=0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]time.Duration{
+		analysisStagePackages: 30 * time.Second,
+		analysisStageTypes:    5 * time.Minute,
+		analysisStagePclntab:  0,
+	}
+	for stage, budget := range expected {
+		if settings.stageBudget(stage) != budget {
+			t.Errorf("expected a budget of %v for %s, got %v", budget, stage, settings.stageBudget(stage))
+		}
+	}
+	var defaults *Settings
+	if defaults.stageBudget(analysisStageTypes) != defaultStageBudget {
+		t.Errorf("expected the default budget without settings, got %v", defaults.stageBudget(analysisStageTypes))
+	}
+
+	_, err = LoadSettings([]string{"PLUGIN_GOINFO_STAGE_BUDGET_TYPES=soon"})
+	if err == nil {
+		t.Errorf("expected an error for an invalid budget")
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	settings, err := ParseSettings(map[string]string{
+		"content_filter":             "executable/linux/elf64",
+		"goinfo_profile":             "fast",
+		"goinfo_group_types":         "true",
+		"goinfo_stage_budget_types":  "5m",
+		"goinfo_feature_cap_go_type": "20",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settings.groupEnabled(groupTypes) || settings.groupEnabled(groupFunctions) {
+		t.Errorf("expected the fast profile with types turned on, got %v", settings.featureGroups)
+	}
+	if settings.stageBudget(analysisStageTypes) != 5*time.Minute || settings.featureCap("go_type") != 20 {
+		t.Errorf("expected the overridden budget and cap, got %v %v", settings.stageBudget(analysisStageTypes), settings.featureCap("go_type"))
+	}
+
+	// The registered defaults are the same as having no settings.
+	settings, err = ParseSettings(SettingDefaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var defaults *Settings
+	if settings.stageBudget(analysisStageTypes) != defaults.stageBudget(analysisStageTypes) || settings.featureCap("go_type") != defaults.featureCap("go_type") || !settings.groupEnabled(groupTypes) {
+		t.Errorf("expected the registered defaults to match the built in defaults")
+	}
+}
+
+func TestCallGoreContext(t *testing.T) {
+	session := &goreSession{contentFilePath: "test.bin"}
+	value, err := callGoreContext(context.Background(), session, analysisStageTypes, func() (int, error) { panic("bad file") })
+	var panicErr *gorePanicError
+	if value != 0 || !errors.As(err, &panicErr) || isStageStopped(err) {
+		t.Errorf("expected the panic to be recovered, got %v %v", value, err)
+	}
+
+	value, err = callGoreContext(context.Background(), session, analysisStageTypes, func() (int, error) { return 1, nil })
+	if value != 1 || err != nil {
+		t.Errorf("expected the call result, got %v %v", value, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	finished := false
+	_, err = callGoreContext(ctx, session, analysisStageTypes, func() (int, error) {
+		<-release
+		finished = true
+		return 1, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || !isStageStopped(err) {
+		t.Errorf("expected the call to stop at the deadline, got %v", err)
+	}
+
+	// The abandoned call still has the file, so later calls are refused without running.
+	called := false
+	_, err = callGoreContext(context.Background(), session, analysisStagePackages, func() (int, error) {
+		called = true
+		return 1, nil
+	})
+	if called || !errors.Is(err, context.DeadlineExceeded) || !isStageStopped(err) {
+		t.Errorf("expected the call after an abandoned call to be refused, got %v", err)
+	}
+
+	close(release)
+	session.calls.Wait()
+	if !finished {
+		t.Errorf("expected the session to wait for the abandoned call")
+	}
+}
@@ -0,0 +1,412 @@
+package goinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Categories of structural anomalies, used as the label of the malformed feature.
+const (
+	anomalyTruncatedHeader         = "truncated_header"
+	anomalySectionHeadersBeyondEOF = "section_headers_beyond_eof"
+	anomalyProgramHeadersBeyondEOF = "program_headers_beyond_eof"
+	anomalyLoadCommandsBeyondEOF   = "load_commands_beyond_eof"
+	anomalyTruncatedSection        = "truncated_section"
+	anomalyTruncatedSegment        = "truncated_segment"
+	anomalyOverlappingSegments     = "overlapping_segments"
+	anomalyOverlappingSections     = "overlapping_sections"
+	anomalyPclntabOutsideFile      = "pclntab_outside_file"
+	anomalyModuledataTextMismatch  = "moduledata_text_mismatch"
+)
+
+// Only the first few pclntab candidates are checked for a moduledata so large files stay fast.
+const maxStructurePclntabCandidates = 16
+
+// A structural problem with an executable, often caused by deliberate anti-analysis corruption.
+type structuralAnomaly struct {
+	category string
+	detail   string
+}
+
+// A part of the file that is mapped into memory when the executable is loaded.
+type mappedRegion struct {
+	name       string
+	address    uint64
+	size       uint64
+	fileOffset uint64
+	fileSize   uint64
+	executable bool
+}
+
+// Collects the anomalies and mapped regions found while walking the headers of a file.
+type structureValidator struct {
+	data      []byte
+	anomalies []structuralAnomaly
+	regions   []mappedRegion
+}
+
+func (sv *structureValidator) add(category string, format string, args ...any) {
+	sv.anomalies = append(sv.anomalies, structuralAnomaly{category: category, detail: fmt.Sprintf(format, args...)})
+}
+
+// Returns true if a range of the file extends past its end.
+func (sv *structureValidator) beyondEOF(offset uint64, size uint64) bool {
+	return offset > uint64(len(sv.data)) || size > uint64(len(sv.data))-offset
+}
+
+// Report every pair of regions whose addresses overlap.
+func (sv *structureValidator) checkOverlaps(category string, kind string) {
+	regions := []mappedRegion{}
+	for _, region := range sv.regions {
+		if region.size > 0 {
+			regions = append(regions, region)
+		}
+	}
+	sort.SliceStable(regions, func(i, j int) bool { return regions[i].address < regions[j].address })
+	for i := 1; i < len(regions); i++ {
+		previous := regions[i-1]
+		if regions[i].address < previous.address+previous.size {
+			sv.add(category, "%s %s at 0x%x overlaps %s %s at 0x%x-0x%x", kind, regions[i].name, regions[i].address, kind, previous.name, previous.address, previous.address+previous.size)
+		}
+	}
+}
+
+// Convert an address to a file offset using the mapped regions.
+func (sv *structureValidator) addressToFileOffset(address uint64) (uint64, bool) {
+	for _, region := range sv.regions {
+		if address >= region.address && address-region.address < region.fileSize {
+			return region.fileOffset + address - region.address, true
+		}
+	}
+	return 0, false
+}
+
+// Check the moduledata of the Go runtime points at a pclntab and text range that are in the file.
+func (sv *structureValidator) checkModuledata() {
+	if len(sv.regions) == 0 {
+		return
+	}
+	candidates := findPclntabCandidates(sv.data)
+	if len(candidates) > maxStructurePclntabCandidates {
+		candidates = candidates[:maxStructurePclntabCandidates]
+	}
+	moduledatas := findModuledatas(sv.data, candidates)
+	for i, candidate := range candidates {
+		moduledataOffset := moduledatas[i].offset
+		if moduledataOffset < 0 {
+			continue
+		}
+		header := sv.data[candidate:]
+		pclntabAddress := readMemoryWord(sv.data, moduledataOffset, int(header[7]), pclntabByteOrder(header))
+		if _, ok := sv.addressToFileOffset(pclntabAddress); !ok {
+			sv.add(anomalyPclntabOutsideFile, "moduledata at offset 0x%x points at pclntab address 0x%x which is not mapped from the file", moduledataOffset, pclntabAddress)
+		}
+		text, etext := moduledataTextRange(sv.data, candidate, moduledataOffset)
+		inText := false
+		for _, region := range sv.regions {
+			if region.executable && text >= region.address && etext >= text && etext <= region.address+region.size {
+				inText = true
+			}
+		}
+		if !inText {
+			sv.add(anomalyModuledataTextMismatch, "moduledata text range 0x%x-0x%x is not inside an executable segment", text, etext)
+		}
+		return
+	}
+}
+
+// Check the headers of an executable for truncation, overlaps and inconsistent Go runtime metadata.
+// Files in formats other than ELF, PE and thin Mach-O are not checked.
+func validateStructure(contentFilePath string) ([]structuralAnomaly, error) {
+	data, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return nil, err
+	}
+	sv := &structureValidator{data: data}
+	switch {
+	case bytes.HasPrefix(data, []byte("\x7fELF")):
+		sv.validateElf()
+	case bytes.HasPrefix(data, []byte("MZ")):
+		sv.validatePE()
+	case bytes.HasPrefix(data, []byte{0xce, 0xfa, 0xed, 0xfe}), bytes.HasPrefix(data, []byte{0xcf, 0xfa, 0xed, 0xfe}),
+		bytes.HasPrefix(data, []byte{0xfe, 0xed, 0xfa, 0xce}), bytes.HasPrefix(data, []byte{0xfe, 0xed, 0xfa, 0xcf}):
+		sv.validateMacho()
+	default:
+		return nil, nil
+	}
+	sv.checkModuledata()
+	return sv.anomalies, nil
+}
+
+// Walk the ELF headers directly, debug/elf refuses to open files with most of these problems.
+func (sv *structureValidator) validateElf() {
+	data := sv.data
+	if len(data) < 52 {
+		sv.add(anomalyTruncatedHeader, "ELF header is truncated at %d bytes", len(data))
+		return
+	}
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if data[5] == 2 {
+		byteOrder = binary.BigEndian
+	}
+	is64 := data[4] == 2
+	if is64 && len(data) < 64 {
+		sv.add(anomalyTruncatedHeader, "ELF header is truncated at %d bytes", len(data))
+		return
+	}
+	word := func(offset uint64) uint64 {
+		if is64 {
+			return byteOrder.Uint64(data[offset:])
+		}
+		return uint64(byteOrder.Uint32(data[offset:]))
+	}
+	var phoff, shoff uint64
+	var phentsize, phnum, shentsize, shnum, shstrndx uint64
+	if is64 {
+		phoff, shoff = word(0x20), word(0x28)
+		phentsize, phnum = uint64(byteOrder.Uint16(data[0x36:])), uint64(byteOrder.Uint16(data[0x38:]))
+		shentsize, shnum, shstrndx = uint64(byteOrder.Uint16(data[0x3a:])), uint64(byteOrder.Uint16(data[0x3c:])), uint64(byteOrder.Uint16(data[0x3e:]))
+	} else {
+		phoff, shoff = word(0x1c), word(0x20)
+		phentsize, phnum = uint64(byteOrder.Uint16(data[0x2a:])), uint64(byteOrder.Uint16(data[0x2c:]))
+		shentsize, shnum, shstrndx = uint64(byteOrder.Uint16(data[0x2e:])), uint64(byteOrder.Uint16(data[0x30:])), uint64(byteOrder.Uint16(data[0x32:]))
+	}
+	minPhentsize, minShentsize := uint64(32), uint64(40)
+	if is64 {
+		minPhentsize, minShentsize = 56, 64
+	}
+
+	if phnum > 0 {
+		if phentsize < minPhentsize || sv.beyondEOF(phoff, phnum*phentsize) {
+			sv.add(anomalyProgramHeadersBeyondEOF, "%d program headers at offset 0x%x extend past the end of the file", phnum, phoff)
+		} else {
+			for i := uint64(0); i < phnum; i++ {
+				header := phoff + i*phentsize
+				// PT_LOAD is the only segment type mapped from the file.
+				if byteOrder.Uint32(data[header:]) != 1 {
+					continue
+				}
+				var offset, address, fileSize, memorySize, flags uint64
+				if is64 {
+					flags = uint64(byteOrder.Uint32(data[header+4:]))
+					offset, address, fileSize, memorySize = word(header+8), word(header+16), word(header+32), word(header+40)
+				} else {
+					offset, address, fileSize, memorySize = word(header+4), word(header+8), word(header+16), word(header+20)
+					flags = uint64(byteOrder.Uint32(data[header+24:]))
+				}
+				name := fmt.Sprintf("%d", i)
+				if sv.beyondEOF(offset, fileSize) {
+					sv.add(anomalyTruncatedSegment, "segment %s at offset 0x%x with size 0x%x extends past the end of the file", name, offset, fileSize)
+				}
+				sv.regions = append(sv.regions, mappedRegion{name: name, address: address, size: memorySize, fileOffset: offset, fileSize: fileSize, executable: flags&1 != 0})
+			}
+		}
+	}
+	sv.checkOverlaps(anomalyOverlappingSegments, "segment")
+
+	if shnum == 0 {
+		return
+	}
+	if shentsize < minShentsize || sv.beyondEOF(shoff, shnum*shentsize) {
+		sv.add(anomalySectionHeadersBeyondEOF, "%d section headers at offset 0x%x extend past the end of the file", shnum, shoff)
+		return
+	}
+	sectionRange := func(index uint64) (uint64, uint64, uint64, uint64) {
+		header := shoff + index*shentsize
+		sectionType := uint64(byteOrder.Uint32(data[header+4:]))
+		if is64 {
+			return sectionType, uint64(byteOrder.Uint32(data[header:])), word(header + 24), word(header + 32)
+		}
+		return sectionType, uint64(byteOrder.Uint32(data[header:])), word(header + 16), word(header + 20)
+	}
+	var names []byte
+	if shstrndx < shnum {
+		_, _, namesOffset, namesSize := sectionRange(shstrndx)
+		if !sv.beyondEOF(namesOffset, namesSize) {
+			names = data[namesOffset : namesOffset+namesSize]
+		}
+	}
+	for i := uint64(0); i < shnum; i++ {
+		sectionType, nameOffset, offset, size := sectionRange(i)
+		// SHT_NOBITS sections take no space in the file.
+		if sectionType == 8 || sectionType == 0 || size == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%d", i)
+		if nameOffset < uint64(len(names)) {
+			if end := bytes.IndexByte(names[nameOffset:], 0); end > 0 {
+				name = string(names[nameOffset : nameOffset+uint64(end)])
+			}
+		}
+		if !sv.beyondEOF(offset, size) {
+			continue
+		}
+		if name == ".gopclntab" {
+			sv.add(anomalyPclntabOutsideFile, "section %s at offset 0x%x with size 0x%x extends past the end of the file", name, offset, size)
+		} else {
+			sv.add(anomalyTruncatedSection, "section %s at offset 0x%x with size 0x%x extends past the end of the file", name, offset, size)
+		}
+	}
+}
+
+// Walk the PE section table directly.
+func (sv *structureValidator) validatePE() {
+	data := sv.data
+	if len(data) < 64 {
+		sv.add(anomalyTruncatedHeader, "DOS header is truncated at %d bytes", len(data))
+		return
+	}
+	peHeaderOffset := uint64(binary.LittleEndian.Uint32(data[0x3c:]))
+	if sv.beyondEOF(peHeaderOffset, 24) {
+		sv.add(anomalyTruncatedHeader, "PE header at offset 0x%x is past the end of the file", peHeaderOffset)
+		return
+	}
+	if string(data[peHeaderOffset:peHeaderOffset+4]) != "PE\x00\x00" {
+		return
+	}
+	coffHeader := data[peHeaderOffset+4:]
+	sectionCount := uint64(binary.LittleEndian.Uint16(coffHeader[2:]))
+	optionalHeaderSize := uint64(binary.LittleEndian.Uint16(coffHeader[16:]))
+	optionalHeaderOffset := peHeaderOffset + 24
+	sectionHeadersOffset := optionalHeaderOffset + optionalHeaderSize
+	if sv.beyondEOF(sectionHeadersOffset, sectionCount*peSectionHeaderSize) {
+		sv.add(anomalySectionHeadersBeyondEOF, "%d section headers at offset 0x%x extend past the end of the file", sectionCount, sectionHeadersOffset)
+		return
+	}
+	imageBase := uint64(0)
+	if optionalHeaderSize >= 32 {
+		switch binary.LittleEndian.Uint16(data[optionalHeaderOffset:]) {
+		case 0x10b:
+			imageBase = uint64(binary.LittleEndian.Uint32(data[optionalHeaderOffset+28:]))
+		case 0x20b:
+			imageBase = binary.LittleEndian.Uint64(data[optionalHeaderOffset+24:])
+		}
+	}
+
+	for i := uint64(0); i < sectionCount; i++ {
+		header := data[sectionHeadersOffset+i*peSectionHeaderSize:]
+		name := strings.TrimRight(string(header[:8]), "\x00")
+		virtualSize := uint64(binary.LittleEndian.Uint32(header[8:]))
+		virtualAddress := uint64(binary.LittleEndian.Uint32(header[12:]))
+		rawSize := uint64(binary.LittleEndian.Uint32(header[16:]))
+		rawPointer := uint64(binary.LittleEndian.Uint32(header[20:]))
+		characteristics := binary.LittleEndian.Uint32(header[36:])
+		if rawSize > 0 && sv.beyondEOF(rawPointer, rawSize) {
+			sv.add(anomalyTruncatedSection, "section %s at offset 0x%x with size 0x%x extends past the end of the file", name, rawPointer, rawSize)
+		}
+		if virtualSize == 0 {
+			virtualSize = rawSize
+		}
+		// IMAGE_SCN_CNT_CODE or IMAGE_SCN_MEM_EXECUTE.
+		executable := characteristics&0x20 != 0 || characteristics&0x20000000 != 0
+		sv.regions = append(sv.regions, mappedRegion{
+			name:       name,
+			address:    imageBase + virtualAddress,
+			size:       virtualSize,
+			fileOffset: rawPointer,
+			fileSize:   min(rawSize, virtualSize),
+			executable: executable,
+		})
+	}
+	sv.checkOverlaps(anomalyOverlappingSections, "section")
+}
+
+// Walk the Mach-O load commands directly.
+func (sv *structureValidator) validateMacho() {
+	data := sv.data
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if data[0] == 0xfe {
+		byteOrder = binary.BigEndian
+	}
+	is64 := byteOrder.Uint32(data) == 0xfeedfacf
+	headerSize := uint64(28)
+	if is64 {
+		headerSize = 32
+	}
+	if uint64(len(data)) < headerSize {
+		sv.add(anomalyTruncatedHeader, "Mach-O header is truncated at %d bytes", len(data))
+		return
+	}
+	commandCount := uint64(byteOrder.Uint32(data[16:]))
+	commandsSize := uint64(byteOrder.Uint32(data[20:]))
+	if sv.beyondEOF(headerSize, commandsSize) {
+		sv.add(anomalyLoadCommandsBeyondEOF, "%d load commands with size 0x%x extend past the end of the file", commandCount, commandsSize)
+		return
+	}
+	word := func(offset uint64) uint64 {
+		if is64 {
+			return byteOrder.Uint64(data[offset:])
+		}
+		return uint64(byteOrder.Uint32(data[offset:]))
+	}
+	commandsEnd := headerSize + commandsSize
+	offset := headerSize
+	for i := uint64(0); i < commandCount && offset+8 <= commandsEnd; i++ {
+		command := byteOrder.Uint32(data[offset:])
+		commandSize := uint64(byteOrder.Uint32(data[offset+4:]))
+		if commandSize < 8 || offset+commandSize > commandsEnd {
+			sv.add(anomalyLoadCommandsBeyondEOF, "load command %d at offset 0x%x with size 0x%x extends past the load commands", i, offset, commandSize)
+			return
+		}
+		// LC_SEGMENT and LC_SEGMENT_64.
+		if (command == 0x1 && !is64 && commandSize >= 56) || (command == 0x19 && is64 && commandSize >= 72) {
+			sv.validateMachoSegment(data[offset:offset+commandSize], byteOrder, is64, word, offset)
+		}
+		offset += commandSize
+	}
+	sv.checkOverlaps(anomalyOverlappingSegments, "segment")
+}
+
+func (sv *structureValidator) validateMachoSegment(command []byte, byteOrder binary.ByteOrder, is64 bool, word func(uint64) uint64, commandOffset uint64) {
+	segmentName := strings.TrimRight(string(command[8:24]), "\x00")
+	var address, size, fileOffset, fileSize, sectionCount, sectionsStart, sectionSize uint64
+	var protection uint32
+	if is64 {
+		address, size, fileOffset, fileSize = word(commandOffset+24), word(commandOffset+32), word(commandOffset+40), word(commandOffset+48)
+		protection = byteOrder.Uint32(command[60:])
+		sectionCount = uint64(byteOrder.Uint32(command[64:]))
+		sectionsStart, sectionSize = 72, 80
+	} else {
+		address, size, fileOffset, fileSize = word(commandOffset+24), word(commandOffset+28), word(commandOffset+32), word(commandOffset+36)
+		protection = byteOrder.Uint32(command[44:])
+		sectionCount = uint64(byteOrder.Uint32(command[48:]))
+		sectionsStart, sectionSize = 56, 68
+	}
+	if sv.beyondEOF(fileOffset, fileSize) {
+		sv.add(anomalyTruncatedSegment, "segment %s at offset 0x%x with size 0x%x extends past the end of the file", segmentName, fileOffset, fileSize)
+	}
+	sv.regions = append(sv.regions, mappedRegion{name: segmentName, address: address, size: size, fileOffset: fileOffset, fileSize: fileSize, executable: protection&4 != 0})
+
+	for i := uint64(0); i < sectionCount && sectionsStart+(i+1)*sectionSize <= uint64(len(command)); i++ {
+		section := command[sectionsStart+i*sectionSize:]
+		sectionName := strings.TrimRight(string(section[:16]), "\x00")
+		var sectionDataSize, sectionOffset uint64
+		var flags uint32
+		if is64 {
+			sectionDataSize = byteOrder.Uint64(section[40:])
+			sectionOffset = uint64(byteOrder.Uint32(section[48:]))
+			flags = byteOrder.Uint32(section[64:])
+		} else {
+			sectionDataSize = uint64(byteOrder.Uint32(section[36:]))
+			sectionOffset = uint64(byteOrder.Uint32(section[40:]))
+			flags = byteOrder.Uint32(section[56:])
+		}
+		// Zero fill sections take no space in the file.
+		switch flags & 0xff {
+		case 0x1, 0xc, 0x12:
+			continue
+		}
+		if sectionDataSize == 0 || !sv.beyondEOF(sectionOffset, sectionDataSize) {
+			continue
+		}
+		if sectionName == "__gopclntab" {
+			sv.add(anomalyPclntabOutsideFile, "section %s at offset 0x%x with size 0x%x extends past the end of the file", sectionName, sectionOffset, sectionDataSize)
+		} else {
+			sv.add(anomalyTruncatedSection, "section %s at offset 0x%x with size 0x%x extends past the end of the file", sectionName, sectionOffset, sectionDataSize)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package goinfo
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Apply a corruption to a copy of a binary and return the path to the copy.
+func corruptTestBinary(t *testing.T, binaryPath string, corrupt func(data []byte) []byte) string {
+	t.Helper()
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corruptedPath := filepath.Join(t.TempDir(), "corrupted.bin")
+	err = os.WriteFile(corruptedPath, corrupt(data), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return corruptedPath
+}
+
+func anomalyCategories(t *testing.T, filePath string) map[string]int {
+	t.Helper()
+	anomalies, err := validateStructure(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	categories := map[string]int{}
+	for _, anomaly := range anomalies {
+		categories[anomaly.category]++
+	}
+	return categories
+}
+
+func TestValidateStructureClean(t *testing.T) {
+	testCases := []struct {
+		goos   string
+		goarch string
+	}{
+		{"linux", "amd64"},
+		{"linux", "386"},
+		{"linux", "arm64"},
+		{"linux", "ppc64"},
+		{"windows", "amd64"},
+		{"windows", "386"},
+		{"darwin", "amd64"},
+		{"darwin", "arm64"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.goos+"_"+tc.goarch, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch})
+			anomalies, err := validateStructure(binaryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(anomalies) != 0 {
+				t.Errorf("expected no anomalies in a clean binary, got %v", anomalies)
+			}
+		})
+	}
+}
+
+func TestValidateStructureElf(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=linux", "GOARCH=amd64"})
+	elfFile, err := elf.Open(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loads := []int{}
+	for i, prog := range elfFile.Progs {
+		if prog.Type == elf.PT_LOAD {
+			loads = append(loads, i)
+		}
+	}
+	pclntab := elfFile.Section(".gopclntab")
+	elfFile.Close()
+
+	// Anti-analysis corruption that stops debug/elf opening the file.
+	corruptedPath := corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		binary.LittleEndian.PutUint64(data[0x28:], uint64(len(data))+0x10000)
+		return data
+	})
+	if _, err := elf.Open(corruptedPath); err == nil {
+		t.Fatalf("expected debug/elf to reject the corrupted section header offset")
+	}
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalySectionHeadersBeyondEOF] != 1 || len(categories) != 1 {
+		t.Errorf("expected only section_headers_beyond_eof, got %v", categories)
+	}
+	// The Go metadata is still found by scanning the file.
+	pclntabData, textStart, err := locatePclntab(corruptedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := parsePclntab(pclntabData, textStart)
+	if err != nil || table.LookupFunc("main.main") == nil || table.LookupFunc("main.main").Entry < textStart || textStart == 0 {
+		t.Errorf("expected main.main to be found in the corrupted file: %v", err)
+	}
+
+	// The second loadable segment moved on top of the first.
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		phoff := binary.LittleEndian.Uint64(data[0x20:])
+		first := phoff + uint64(loads[0])*56
+		second := phoff + uint64(loads[1])*56
+		copy(data[second+16:second+24], data[first+16:first+24])
+		return data
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyOverlappingSegments] == 0 {
+		t.Errorf("expected overlapping_segments, got %v", categories)
+	}
+
+	// The pclntab section points past the end of the file.
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		shoff := binary.LittleEndian.Uint64(data[0x28:])
+		for i := uint64(0); i < uint64(binary.LittleEndian.Uint16(data[0x3c:])); i++ {
+			header := shoff + i*64
+			if binary.LittleEndian.Uint64(data[header+24:]) == pclntab.Offset {
+				binary.LittleEndian.PutUint64(data[header+24:], uint64(len(data)))
+			}
+		}
+		return data
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyPclntabOutsideFile] != 1 {
+		t.Errorf("expected pclntab_outside_file, got %v", categories)
+	}
+
+	// The end of the file cut off.
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		return data[:len(data)-len(data)/4]
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyTruncatedSection] == 0 && categories[anomalySectionHeadersBeyondEOF] == 0 {
+		t.Errorf("expected truncated sections, got %v", categories)
+	}
+
+	// The moduledata text range moved away from the code.
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		pclntabOffset := int(pclntab.Offset)
+		moduledataOffset, _ := findModuledata(data, pclntabOffset)
+		if moduledataOffset < 0 {
+			t.Fatal("moduledata not found")
+		}
+		binary.LittleEndian.PutUint64(data[moduledataOffset+22*8:], 0x10)
+		return data
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyModuledataTextMismatch] != 1 {
+		t.Errorf("expected moduledata_text_mismatch, got %v", categories)
+	}
+}
+
+func TestValidateStructurePE(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=windows", "GOARCH=amd64"})
+	corruptedPath := corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		peHeaderOffset := binary.LittleEndian.Uint32(data[0x3c:])
+		optionalHeaderSize := uint32(binary.LittleEndian.Uint16(data[peHeaderOffset+20:]))
+		firstSection := peHeaderOffset + 24 + optionalHeaderSize
+		// Push the first section's data past the end and make the second overlap the first.
+		binary.LittleEndian.PutUint32(data[firstSection+20:], uint32(len(data)))
+		copy(data[firstSection+peSectionHeaderSize+12:firstSection+peSectionHeaderSize+16], data[firstSection+12:firstSection+16])
+		return data
+	})
+	categories := anomalyCategories(t, corruptedPath)
+	if categories[anomalyTruncatedSection] != 1 || categories[anomalyOverlappingSections] == 0 {
+		t.Errorf("expected truncated_section and overlapping_sections, got %v", categories)
+	}
+
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		peHeaderOffset := binary.LittleEndian.Uint32(data[0x3c:])
+		binary.LittleEndian.PutUint16(data[peHeaderOffset+6:], 0xffff)
+		return data
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalySectionHeadersBeyondEOF] != 1 {
+		t.Errorf("expected section_headers_beyond_eof, got %v", categories)
+	}
+}
+
+func TestValidateStructureMacho(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=darwin", "GOARCH=arm64"})
+	corruptedPath := corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		binary.LittleEndian.PutUint32(data[20:], uint32(len(data)))
+		return data
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyLoadCommandsBeyondEOF] != 1 {
+		t.Errorf("expected load_commands_beyond_eof, got %v", categories)
+	}
+
+	corruptedPath = corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+		return data[:len(data)/2]
+	})
+	if categories := anomalyCategories(t, corruptedPath); categories[anomalyTruncatedSegment] == 0 {
+		t.Errorf("expected truncated_segment, got %v", categories)
+	}
+}
+
+func TestValidateStructureTruncatedHeader(t *testing.T) {
+	testCases := []struct {
+		goos   string
+		goarch string
+		size   int
+	}{
+		{"linux", "amd64", 40},
+		{"linux", "amd64", 60},
+		{"linux", "386", 48},
+		{"windows", "amd64", 48},
+		{"windows", "amd64", 0x80},
+		{"darwin", "arm64", 24},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s_%s_%d", tc.goos, tc.goarch, tc.size), func(t *testing.T) {
+			binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=" + tc.goos, "GOARCH=" + tc.goarch})
+			corruptedPath := corruptTestBinary(t, binaryPath, func(data []byte) []byte {
+				return data[:tc.size]
+			})
+			if categories := anomalyCategories(t, corruptedPath); categories[anomalyTruncatedHeader] != 1 || len(categories) != 1 {
+				t.Errorf("expected only truncated_header, got %v", categories)
+			}
+		})
+	}
+}
+
+func TestValidateStructureOtherFormats(t *testing.T) {
+	anomalies, err := validateStructure("structure.go")
+	if err != nil || anomalies != nil {
+		t.Errorf("expected no validation of a text file, got %v %v", anomalies, err)
+	}
+}
@@ -0,0 +1,199 @@
+package goinfo
+
+import (
+	"debug/gosym"
+	"regexp"
+	"strings"
+)
+
+// Parts of a Go function symbol such as 'github.com/a/b.(*List[...]).Push.func1'.
+type goSymbol struct {
+	packagePath string
+	// Receiver type without the pointer or type arguments, empty for functions.
+	receiver        string
+	pointerReceiver bool
+	// Function or method name without type arguments.
+	name string
+	// Type arguments of a generic instantiation, e.g. 'go.shape.int' or '...'.
+	typeArguments string
+	// Closures and numbered functions within the function, e.g. 'func1.2' or the '0' of 'init.0'.
+	closure string
+	// Set for method values, which the compiler names with a '-fm' suffix.
+	methodValue bool
+}
+
+// Parts of a symbol that are compiler generated functions nested in the one before them.
+var closurePartPattern = regexp.MustCompile(`^(func\d+|\d+|deferwrap\d+|gowrap\d+)$`)
+
+// Split a symbol on the dots that aren't inside brackets or parentheses.
+// An unbalanced bracket, as found in garbled names, keeps the rest of the symbol in one part.
+func splitSymbolParts(symbol string) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i, c := range symbol {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, symbol[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, symbol[start:])
+}
+
+// Split a type or function name from its instantiation brackets, e.g. 'List[...]' gives 'List' and '...'.
+func splitTypeArguments(name string) (string, string) {
+	open := strings.Index(name, "[")
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return name, ""
+	}
+	return name[:open], name[open+1 : len(name)-1]
+}
+
+// Parse a Go function symbol into its package path, receiver, name, closure and instantiation.
+// The package path ends at the first dot after its last slash, where the slash must come before any
+// bracket because type arguments and garbled names can hold other package paths.
+// The linker escapes dots in the last element of a package path, e.g. 'gopkg.in/yaml%2ev2'.
+func parseGoSymbol(symbol string) goSymbol {
+	parsed := goSymbol{}
+	limit := strings.IndexAny(symbol, "([")
+	if limit < 0 {
+		limit = len(symbol)
+	}
+	pathEnd := strings.LastIndex(symbol[:limit], "/") + 1
+	dot := strings.Index(symbol[pathEnd:limit], ".")
+	if dot < 0 {
+		parsed.name, parsed.typeArguments = splitTypeArguments(symbol)
+		return parsed
+	}
+	parsed.packagePath = strings.ReplaceAll(symbol[:pathEnd+dot], "%2e", ".")
+	parts := splitSymbolParts(symbol[pathEnd+dot+1:])
+
+	if strings.HasPrefix(parts[0], "(") && strings.HasSuffix(parts[0], ")") && len(parts) > 1 {
+		receiver := strings.TrimSuffix(strings.TrimPrefix(parts[0], "("), ")")
+		parsed.pointerReceiver = strings.HasPrefix(receiver, "*")
+		parsed.receiver, parsed.typeArguments = splitTypeArguments(strings.TrimPrefix(receiver, "*"))
+		parts = parts[1:]
+	} else if len(parts) > 1 && parts[1] != "" && !closurePartPattern.MatchString(strings.TrimSuffix(parts[1], "-fm")) {
+		// Value receivers aren't wrapped in parentheses, e.g. 'main.Config.String'.
+		parsed.receiver, parsed.typeArguments = splitTypeArguments(parts[0])
+		parts = parts[1:]
+	}
+	last := len(parts) - 1
+	if strings.HasSuffix(parts[last], "-fm") {
+		parsed.methodValue = true
+		parts[last] = strings.TrimSuffix(parts[last], "-fm")
+	}
+	name, typeArguments := splitTypeArguments(parts[0])
+	parsed.name = name
+	if typeArguments != "" {
+		parsed.typeArguments = typeArguments
+	}
+	parsed.closure = strings.Join(parts[1:], ".")
+	return parsed
+}
+
+// Name of the function or method including its closure and method value suffix, e.g. 'Send.func1-fm'.
+func (s goSymbol) functionName() string {
+	name := s.name
+	if s.closure != "" {
+		name += "." + s.closure
+	}
+	if s.methodValue {
+		name += "-fm"
+	}
+	return name
+}
+
+// Receiver as written in a symbol, e.g. '(*Agent)' for a pointer receiver or 'Agent' for a value receiver.
+func (s goSymbol) receiverName() string {
+	receiver := s.receiver
+	if s.receiver != "" && s.typeArguments != "" {
+		receiver += "[" + s.typeArguments + "]"
+	}
+	if s.pointerReceiver {
+		return "(*" + receiver + ")"
+	}
+	return receiver
+}
+
+// Receiver type qualified by its package, e.g. 'main.Agent'.
+func (s goSymbol) qualifiedReceiver() string {
+	if s.packagePath == "" {
+		return s.receiver
+	}
+	return s.packagePath + "." + s.receiver
+}
+
+// Label of the go_method_receiver feature saying whether the receiver is a pointer.
+func (s goSymbol) receiverKind() string {
+	if s.pointerReceiver {
+		return "pointer"
+	}
+	return "value"
+}
+
+// Package path of a package name gore reported, cutting off the rest of a symbol left in garbled names
+// such as 'vendor/golang_org/x/net/route.(*wireFormat).(vendor/golang_org/x/net/route'.
+func goPackagePath(name string) string {
+	if !strings.ContainsAny(name, "([") {
+		return name
+	}
+	parsed := parseGoSymbol(name)
+	if parsed.packagePath == "" {
+		return name
+	}
+	return parsed.packagePath
+}
+
+// Parse a method gore found from its package, receiver and name.
+// Gore's receivers may or may not be wrapped in parentheses, and closures are given the function they are
+// declared in as their receiver, e.g. '(*Agent).beacon'. Its package names are already unescaped, so they
+// can have dots after the last slash and are kept as they are rather than parsed.
+func parseGoreMethod(packageName string, receiver string, name string) goSymbol {
+	if strings.HasPrefix(receiver, "(") && strings.Index(receiver, ")") == len(receiver)-1 {
+		receiver = receiver[1 : len(receiver)-1]
+	}
+	if strings.HasPrefix(receiver, "*") {
+		receiver = "(" + receiver + ")"
+	}
+	symbol := "_." + name
+	if receiver != "" {
+		symbol = "_." + receiver + "." + name
+	}
+	parsed := parseGoSymbol(symbol)
+	parsed.packagePath = goPackagePath(packageName)
+	return parsed
+}
+
+// Parse a function or method gore found, using the full symbol in the pclntab when there is one at its entry.
+// Gore splits the symbols of closures in generic functions inside the type arguments, e.g. into the
+// receiver 'Call[go.shape.string]' and name 'go.shape.string].func1'.
+func parseGoreFunction(pclntab *gosym.Table, packageName string, receiver string, name string, entry uint64) goSymbol {
+	if pclntab != nil {
+		if function := pclntab.PCToFunc(entry); function != nil && function.Entry == entry {
+			parsed := parseGoSymbol(function.Name)
+			parsed.packagePath = goPackagePath(packageName)
+			return parsed
+		}
+	}
+	return parseGoreMethod(packageName, receiver, name)
+}
+
+// Add the receiver of a method, linked to the method by its offset.
+func addMethodReceiverFeature(features *featureWriter, method goSymbol, entry uint64, end uint64) {
+	if method.receiver == "" {
+		return
+	}
+	features.addFeatureWithExtra("go_method_receiver", method.qualifiedReceiver(), &featureOptions{
+		Label:  method.receiverKind(),
+		Offset: entry,
+		Size:   end - entry,
+	})
+}
@@ -0,0 +1,118 @@
+package goinfo
+
+import (
+	"testing"
+)
+
+func TestParseGoSymbol(t *testing.T) {
+	testCases := []struct {
+		symbol   string
+		expected goSymbol
+	}{
+		{"main.main", goSymbol{packagePath: "main", name: "main"}},
+		{"main.main.func1.2", goSymbol{packagePath: "main", name: "main", closure: "func1.2"}},
+		{"main.init.0", goSymbol{packagePath: "main", name: "init", closure: "0"}},
+		{"main.(*Config).Send", goSymbol{packagePath: "main", receiver: "Config", pointerReceiver: true, name: "Send"}},
+		{"main.Config.String", goSymbol{packagePath: "main", receiver: "Config", name: "String"}},
+		{"main.(*Config).Send-fm", goSymbol{packagePath: "main", receiver: "Config", pointerReceiver: true, name: "Send", methodValue: true}},
+		{"main.(*Config).Send.func1", goSymbol{packagePath: "main", receiver: "Config", pointerReceiver: true, name: "Send", closure: "func1"}},
+		{"main.run.deferwrap1", goSymbol{packagePath: "main", name: "run", closure: "deferwrap1"}},
+		{"github.com/evil/implant/beacon.(*Agent).beacon", goSymbol{packagePath: "github.com/evil/implant/beacon", receiver: "Agent", pointerReceiver: true, name: "beacon"}},
+		{"gopkg.in/yaml%2ev2.(*parser).parse", goSymbol{packagePath: "gopkg.in/yaml.v2", receiver: "parser", pointerReceiver: true, name: "parse"}},
+		{"main.Map[...]", goSymbol{packagePath: "main", name: "Map", typeArguments: "..."}},
+		{"main.Map[go.shape.int,go.shape.string]", goSymbol{packagePath: "main", name: "Map", typeArguments: "go.shape.int,go.shape.string"}},
+		{"example.com/list.(*List[go.shape.*example.com/x.T]).Push", goSymbol{packagePath: "example.com/list", receiver: "List", pointerReceiver: true, name: "Push", typeArguments: "go.shape.*example.com/x.T"}},
+		{"example.com/list.List[...].Len", goSymbol{packagePath: "example.com/list", receiver: "List", name: "Len", typeArguments: "..."}},
+		{"vendor/golang_org/x/net/route.(*wireFormat).parseInterfaceMessage-fm", goSymbol{packagePath: "vendor/golang_org/x/net/route", receiver: "wireFormat", pointerReceiver: true, name: "parseInterfaceMessage", methodValue: true}},
+		{"runtime.goexit", goSymbol{packagePath: "runtime", name: "goexit"}},
+		{"go:buildid", goSymbol{name: "go:buildid"}},
+	}
+	for _, tc := range testCases {
+		if parsed := parseGoSymbol(tc.symbol); parsed != tc.expected {
+			t.Errorf("parsing %s expected %+v got %+v", tc.symbol, tc.expected, parsed)
+		}
+	}
+}
+
+func TestGoSymbolNames(t *testing.T) {
+	method := parseGoSymbol("example.com/list.(*List[...]).Push.func1-fm")
+	if method.functionName() != "Push.func1-fm" || method.receiverName() != "(*List[...])" ||
+		method.qualifiedReceiver() != "example.com/list.List" || method.receiverKind() != "pointer" {
+		t.Errorf("unexpected names for %+v", method)
+	}
+	valueMethod := parseGoSymbol("main.Config.String")
+	if valueMethod.receiverName() != "Config" || valueMethod.receiverKind() != "value" {
+		t.Errorf("unexpected receiver for %+v", valueMethod)
+	}
+}
+
+func TestGoPackagePath(t *testing.T) {
+	for name, expected := range map[string]string{
+		"vendor/golang_org/x/net/route.(*wireFormat).(vendor/golang_org/x/net/route": "vendor/golang_org/x/net/route",
+		"github.com/evil/implant": "github.com/evil/implant",
+		"gopkg.in/yaml.v2":        "gopkg.in/yaml.v2",
+		"main":                    "main",
+	} {
+		if packagePath := goPackagePath(name); packagePath != expected {
+			t.Errorf("expected %s for %s got %s", expected, name, packagePath)
+		}
+	}
+}
+
+func TestParseGoreMethod(t *testing.T) {
+	for _, receiver := range []string{"(*Chrome)", "*Chrome"} {
+		method := parseGoreMethod("gopkg.in/yaml.v2", receiver, "ChromeParse")
+		expected := goSymbol{packagePath: "gopkg.in/yaml.v2", receiver: "Chrome", pointerReceiver: true, name: "ChromeParse"}
+		if method != expected {
+			t.Errorf("expected %+v for receiver %s got %+v", expected, receiver, method)
+		}
+	}
+	method := parseGoreMethod("main", "Config", "String")
+	if method.receiver != "Config" || method.pointerReceiver || method.name != "String" {
+		t.Errorf("unexpected value receiver method %+v", method)
+	}
+
+	// Gore gives closures the function they are declared in as their receiver.
+	testCases := []struct {
+		receiver string
+		name     string
+		expected goSymbol
+	}{
+		{"main", "func1", goSymbol{packagePath: "main", name: "main", closure: "func1"}},
+		{"(*Agent).beacon", "func1", goSymbol{packagePath: "main", receiver: "Agent", pointerReceiver: true, name: "beacon", closure: "func1"}},
+		{"(*Agent).beacon.func1", "deferwrap1", goSymbol{packagePath: "main", receiver: "Agent", pointerReceiver: true, name: "beacon", closure: "func1.deferwrap1"}},
+		{"Agent.String", "func2", goSymbol{packagePath: "main", receiver: "Agent", name: "String", closure: "func2"}},
+	}
+	for _, tc := range testCases {
+		if method := parseGoreMethod("main", tc.receiver, tc.name); method != tc.expected {
+			t.Errorf("expected %+v for %s %s got %+v", tc.expected, tc.receiver, tc.name, method)
+		}
+	}
+}
+
+func TestParseGoreFunction(t *testing.T) {
+	binaryPath := buildTestProgram(t, corpusSource, []string{"GOOS=linux", "GOARCH=amd64"})
+	pclntabData, textStart, err := locatePclntab(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := parsePclntab(pclntabData, textStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closure := table.LookupFunc("main.(*Agent).beacon.func1")
+	if closure == nil {
+		t.Fatal("expected the corpus program to have a closure in a method")
+	}
+	// The symbol in the pclntab wins over however gore split it.
+	expected := goSymbol{packagePath: "main", receiver: "Agent", pointerReceiver: true, name: "beacon", closure: "func1"}
+	if function := parseGoreFunction(table, "main", "beacon", "func1", closure.Entry); function != expected {
+		t.Errorf("expected %+v from the pclntab got %+v", expected, function)
+	}
+	if function := parseGoreFunction(table, "main", "(*Agent).beacon", "func1", closure.Entry+1); function != expected {
+		t.Errorf("expected %+v from gore's names got %+v", expected, function)
+	}
+	if function := parseGoreFunction(nil, "main", "(*Agent).beacon", "func1", closure.Entry); function != expected {
+		t.Errorf("expected %+v without a pclntab got %+v", expected, function)
+	}
+}
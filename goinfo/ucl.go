@@ -0,0 +1,415 @@
+package goinfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// UPX compression methods, only the NRV methods used for executables are supported.
+const (
+	upxMethodNRV2BLE32 = 2
+	upxMethodNRV2B8    = 3
+	upxMethodNRV2DLE32 = 5
+	upxMethodNRV2D8    = 6
+	upxMethodNRV2ELE32 = 8
+	upxMethodNRV2E8    = 9
+	upxMethodLZMA      = 14
+)
+
+// Get a readable name for a UPX compression method.
+func upxMethodName(method uint8) string {
+	switch method {
+	case upxMethodNRV2BLE32, upxMethodNRV2B8:
+		return "nrv2b"
+	case upxMethodNRV2DLE32, upxMethodNRV2D8:
+		return "nrv2d"
+	case upxMethodNRV2ELE32, upxMethodNRV2E8:
+		return "nrv2e"
+	case upxMethodLZMA:
+		return "lzma"
+	default:
+		return fmt.Sprintf("method_%d", method)
+	}
+}
+
+// Returned for compression methods that can't be decompressed, the file is still known to be UPX packed.
+type uclMethodError struct {
+	method uint8
+}
+
+func (e *uclMethodError) Error() string {
+	return fmt.Sprintf("unsupported UPX compression method %s", upxMethodName(e.method))
+}
+
+var errUclInputOverrun = errors.New("compressed data ended early")
+var errUclOutputOverrun = errors.New("decompressed data is larger than expected")
+var errUclLookbehindOverrun = errors.New("match offset is before the start of the output")
+
+// Reads the bit stream and literal bytes of UCL compressed data.
+type uclReader struct {
+	src  []byte
+	ilen int
+	// Bit buffer, the lowest set bit marks the end of the loaded bits.
+	bb uint32
+	// Read the bit stream 32 bits at a time (LE32 methods) instead of 8.
+	le32 bool
+}
+
+func (r *uclReader) getBit() (uint32, error) {
+	if r.le32 {
+		if r.bb&0x7fffffff == 0 {
+			if r.ilen+4 > len(r.src) {
+				return 0, errUclInputOverrun
+			}
+			value := binary.LittleEndian.Uint32(r.src[r.ilen:])
+			r.ilen += 4
+			r.bb = value<<1 | 1
+			return value >> 31, nil
+		}
+		bit := r.bb >> 31
+		r.bb <<= 1
+		return bit, nil
+	}
+	if r.bb&0x7f == 0 {
+		if r.ilen >= len(r.src) {
+			return 0, errUclInputOverrun
+		}
+		value := uint32(r.src[r.ilen])
+		r.ilen++
+		r.bb = (value<<1 | 1) & 0xff
+		return value >> 7, nil
+	}
+	bit := (r.bb >> 7) & 1
+	r.bb = (r.bb << 1) & 0xff
+	return bit, nil
+}
+
+func (r *uclReader) getByte() (uint32, error) {
+	if r.ilen >= len(r.src) {
+		return 0, errUclInputOverrun
+	}
+	value := r.src[r.ilen]
+	r.ilen++
+	return uint32(value), nil
+}
+
+// Writes decompressed data, refusing to grow past the expected size.
+type uclWriter struct {
+	dst []byte
+	max int
+}
+
+func (w *uclWriter) literal(value uint32) error {
+	if len(w.dst) >= w.max {
+		return errUclOutputOverrun
+	}
+	w.dst = append(w.dst, byte(value))
+	return nil
+}
+
+// Copy length bytes from offset bytes back in the output, the source may overlap the destination.
+func (w *uclWriter) match(offset uint32, length uint32) error {
+	if uint64(offset) > uint64(len(w.dst)) || offset == 0 {
+		return errUclLookbehindOverrun
+	}
+	if len(w.dst)+int(length) > w.max {
+		return errUclOutputOverrun
+	}
+	start := len(w.dst) - int(offset)
+	for i := 0; i < int(length); i++ {
+		w.dst = append(w.dst, w.dst[start+i])
+	}
+	return nil
+}
+
+// Decompress UCL data produced by UPX with one of the NRV methods.
+func uclDecompress(method uint8, src []byte, uncompressedSize int) ([]byte, error) {
+	reader := &uclReader{src: src}
+	switch method {
+	case upxMethodNRV2BLE32, upxMethodNRV2DLE32, upxMethodNRV2ELE32:
+		reader.le32 = true
+	}
+	writer := &uclWriter{dst: make([]byte, 0, uncompressedSize), max: uncompressedSize}
+
+	var err error
+	switch method {
+	case upxMethodNRV2BLE32, upxMethodNRV2B8:
+		err = nrv2bDecompress(reader, writer)
+	case upxMethodNRV2DLE32, upxMethodNRV2D8:
+		err = nrv2dDecompress(reader, writer, false)
+	case upxMethodNRV2ELE32, upxMethodNRV2E8:
+		err = nrv2dDecompress(reader, writer, true)
+	default:
+		return nil, &uclMethodError{method: method}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(writer.dst) != uncompressedSize {
+		return nil, fmt.Errorf("decompressed %d bytes but expected %d", len(writer.dst), uncompressedSize)
+	}
+	return writer.dst, nil
+}
+
+// Copy literal bytes while the bit stream has literal flags set.
+func uclLiterals(reader *uclReader, writer *uclWriter) error {
+	for {
+		bit, err := reader.getBit()
+		if err != nil {
+			return err
+		}
+		if bit == 0 {
+			return nil
+		}
+		value, err := reader.getByte()
+		if err != nil {
+			return err
+		}
+		err = writer.literal(value)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Read an Elias gamma style number from the bit stream, starting with an implicit leading 1.
+func uclGamma(reader *uclReader, value uint32) (uint32, error) {
+	for {
+		bit, err := reader.getBit()
+		if err != nil {
+			return 0, err
+		}
+		value = value*2 + bit
+		bit, err = reader.getBit()
+		if err != nil {
+			return 0, err
+		}
+		if bit != 0 {
+			return value, nil
+		}
+		// Guard against corrupted streams that never terminate the number.
+		if value > 0x1000000 {
+			return 0, errors.New("corrupted length in compressed data")
+		}
+	}
+}
+
+func nrv2bDecompress(reader *uclReader, writer *uclWriter) error {
+	lastOffset := uint32(1)
+	for {
+		err := uclLiterals(reader, writer)
+		if err != nil {
+			return err
+		}
+		offset, err := uclGamma(reader, 1)
+		if err != nil {
+			return err
+		}
+		if offset == 2 {
+			offset = lastOffset
+		} else {
+			low, err := reader.getByte()
+			if err != nil {
+				return err
+			}
+			offset = (offset-3)*256 + low
+			if offset == 0xffffffff {
+				return nil
+			}
+			offset++
+			lastOffset = offset
+		}
+		high, err := reader.getBit()
+		if err != nil {
+			return err
+		}
+		low, err := reader.getBit()
+		if err != nil {
+			return err
+		}
+		length := high*2 + low
+		if length == 0 {
+			length, err = uclGamma(reader, 1)
+			if err != nil {
+				return err
+			}
+			length += 2
+		}
+		if offset > 0xd00 {
+			length++
+		}
+		err = writer.match(offset, length+1)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Decompress NRV2D, or NRV2E which only differs in how match lengths are encoded.
+func nrv2dDecompress(reader *uclReader, writer *uclWriter, nrv2e bool) error {
+	lastOffset := uint32(1)
+	for {
+		err := uclLiterals(reader, writer)
+		if err != nil {
+			return err
+		}
+		// The offset uses a gamma code where each step also reads a bit of the value.
+		offset := uint32(1)
+		for {
+			bit, err := reader.getBit()
+			if err != nil {
+				return err
+			}
+			offset = offset*2 + bit
+			stop, err := reader.getBit()
+			if err != nil {
+				return err
+			}
+			if stop != 0 {
+				break
+			}
+			bit, err = reader.getBit()
+			if err != nil {
+				return err
+			}
+			offset = (offset-1)*2 + bit
+			if offset > 0x1000000 {
+				return errors.New("corrupted offset in compressed data")
+			}
+		}
+		var length uint32
+		if offset == 2 {
+			offset = lastOffset
+			length, err = reader.getBit()
+			if err != nil {
+				return err
+			}
+		} else {
+			low, err := reader.getByte()
+			if err != nil {
+				return err
+			}
+			offset = (offset-3)*256 + low
+			if offset == 0xffffffff {
+				return nil
+			}
+			length = (offset ^ 0xffffffff) & 1
+			offset >>= 1
+			offset++
+			lastOffset = offset
+		}
+
+		if nrv2e {
+			length, err = nrv2eLength(reader, length)
+		} else {
+			length, err = nrv2dLength(reader, length)
+		}
+		if err != nil {
+			return err
+		}
+		if offset > 0x500 {
+			length++
+		}
+		err = writer.match(offset, length+1)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func nrv2dLength(reader *uclReader, length uint32) (uint32, error) {
+	bit, err := reader.getBit()
+	if err != nil {
+		return 0, err
+	}
+	length = length*2 + bit
+	if length != 0 {
+		return length, nil
+	}
+	length, err = uclGamma(reader, 1)
+	if err != nil {
+		return 0, err
+	}
+	return length + 2, nil
+}
+
+func nrv2eLength(reader *uclReader, length uint32) (uint32, error) {
+	if length != 0 {
+		bit, err := reader.getBit()
+		return 1 + bit, err
+	}
+	bit, err := reader.getBit()
+	if err != nil {
+		return 0, err
+	}
+	if bit != 0 {
+		bit, err = reader.getBit()
+		return 3 + bit, err
+	}
+	length, err = uclGamma(reader, 1)
+	if err != nil {
+		return 0, err
+	}
+	return length + 3, nil
+}
+
+// Reverse the x86 call trick filters UPX applies to executable code before compressing it.
+// The filters convert relative call and jump targets to absolute ones so they compress better.
+// Only the filters used for x86 and x86-64 executables are supported, false is returned for others.
+func upxUnfilter(filterID uint8, cto uint8, data []byte, addValue uint32) bool {
+	if filterID == 0 {
+		return true
+	}
+	family := filterID & 0xf0
+	variant := filterID & 0x0f
+	// Variants 1-3 are e8, e9 and e8e9 stored little endian, 4-6 are the same stored big endian.
+	if variant == 0 || (variant > 6 && filterID != 0x49) {
+		return false
+	}
+	calls := variant == 1 || variant == 3 || variant == 4 || variant == 6 || filterID == 0x49
+	jumps := variant == 2 || variant == 3 || variant == 5 || variant == 6 || filterID == 0x49
+	bigEndian := variant >= 4 || filterID == 0x49
+	isBranch := func(i int) bool {
+		switch {
+		case data[i] == 0xe8:
+			return calls
+		case data[i] == 0xe9:
+			return jumps
+		// Conditional jumps (0f 8x) are only filtered by 0x49.
+		case filterID == 0x49 && i > 0 && data[i-1] == 0x0f && data[i]&0xf0 == 0x80:
+			return true
+		}
+		return false
+	}
+	read := binary.LittleEndian.Uint32
+	if bigEndian {
+		read = binary.BigEndian.Uint32
+	}
+
+	switch family {
+	case 0x20:
+		// ct32 converts every branch.
+		for i := 0; i+5 <= len(data); i++ {
+			if !isBranch(i) {
+				continue
+			}
+			target := read(data[i+1:])
+			binary.LittleEndian.PutUint32(data[i+1:], target-uint32(i+1)-addValue)
+			i += 4
+		}
+	case 0x30, 0x40:
+		// ctok32 only converts branches in range and marks them with the cto byte in the high byte.
+		for i := 0; i+5 <= len(data); i++ {
+			if !isBranch(i) || data[i+1] != cto {
+				continue
+			}
+			target := binary.BigEndian.Uint32(data[i+1:]) & 0x00ffffff
+			binary.LittleEndian.PutUint32(data[i+1:], target-uint32(i+1)-addValue)
+			i += 4
+		}
+	default:
+		return false
+	}
+	return true
+}
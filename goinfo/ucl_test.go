@@ -0,0 +1,241 @@
+package goinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// A minimal NRV2B compressor used to produce test data, it only emits literals and matches it is told to.
+type nrv2bTestWriter struct {
+	out        []byte
+	le32       bool
+	wordOffset int
+	bitCount   int
+}
+
+func (w *nrv2bTestWriter) putBit(bit uint32) {
+	wordBits := 8
+	if w.le32 {
+		wordBits = 32
+	}
+	// Reserve space for the next bit word at the point the decoder will read it.
+	if w.bitCount == 0 {
+		w.wordOffset = len(w.out)
+		w.out = append(w.out, make([]byte, wordBits/8)...)
+	}
+	if bit != 0 {
+		shift := wordBits - 1 - w.bitCount
+		if w.le32 {
+			word := binary.LittleEndian.Uint32(w.out[w.wordOffset:])
+			binary.LittleEndian.PutUint32(w.out[w.wordOffset:], word|1<<shift)
+		} else {
+			w.out[w.wordOffset] |= 1 << shift
+		}
+	}
+	w.bitCount = (w.bitCount + 1) % wordBits
+}
+
+// Write a gamma coded number, the leading 1 bit is implicit.
+func (w *nrv2bTestWriter) putGamma(value uint32) {
+	top := 31
+	for value>>top == 0 {
+		top--
+	}
+	for i := top - 1; i >= 0; i-- {
+		w.putBit((value >> i) & 1)
+		if i == 0 {
+			w.putBit(1)
+		} else {
+			w.putBit(0)
+		}
+	}
+}
+
+func (w *nrv2bTestWriter) literal(value byte) {
+	w.putBit(1)
+	w.out = append(w.out, value)
+}
+
+// Copy length bytes from offset bytes back, length must be at least 2 and offset at most 0xd00.
+func (w *nrv2bTestWriter) match(offset uint32, length uint32) {
+	w.putBit(0)
+	offset--
+	w.putGamma(offset>>8 + 3)
+	w.out = append(w.out, byte(offset))
+	length--
+	if length < 4 {
+		w.putBit(length >> 1)
+		w.putBit(length & 1)
+	} else {
+		w.putBit(0)
+		w.putBit(0)
+		w.putGamma(length - 2)
+	}
+}
+
+func (w *nrv2bTestWriter) end() []byte {
+	w.putBit(0)
+	w.putGamma(0x1000002)
+	w.out = append(w.out, 0xff)
+	return w.out
+}
+
+// Compress data with NRV2B, only runs of the same byte are encoded as matches.
+func nrv2bTestCompress(data []byte, le32 bool) []byte {
+	writer := &nrv2bTestWriter{le32: le32}
+	for i := 0; i < len(data); {
+		run := 1
+		for i+run < len(data) && data[i+run] == data[i] && run < 0x10000 {
+			run++
+		}
+		writer.literal(data[i])
+		if run > 3 {
+			writer.match(1, uint32(run-1))
+			i += run
+		} else {
+			i++
+		}
+	}
+	return writer.end()
+}
+
+func TestUclDecompressNRV2B(t *testing.T) {
+	for _, method := range []uint8{upxMethodNRV2BLE32, upxMethodNRV2B8} {
+		writer := &nrv2bTestWriter{le32: method == upxMethodNRV2BLE32}
+		expected := []byte{}
+		for _, value := range []byte("go build ID") {
+			writer.literal(value)
+			expected = append(expected, value)
+		}
+		// Short match, long match and an overlapping run.
+		writer.match(9, 3)
+		expected = append(expected, expected[len(expected)-9:len(expected)-6]...)
+		writer.match(14, 10)
+		expected = append(expected, expected[len(expected)-14:len(expected)-4]...)
+		writer.literal('x')
+		expected = append(expected, 'x')
+		writer.match(1, 40)
+		expected = append(expected, bytes.Repeat([]byte("x"), 40)...)
+
+		actual, err := uclDecompress(method, writer.end(), len(expected))
+		if err != nil {
+			t.Fatalf("method %d: %v", method, err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("method %d: expected %q got %q", method, expected, actual)
+		}
+
+		runs := append(bytes.Repeat([]byte{0}, 5000), []byte("abc\x00\x00\x00\x00\x00d")...)
+		actual, err = uclDecompress(method, nrv2bTestCompress(runs, writer.le32), len(runs))
+		if err != nil || !bytes.Equal(actual, runs) {
+			t.Errorf("method %d: run length data did not round trip: %v", method, err)
+		}
+	}
+}
+
+func TestUclDecompressCorrupted(t *testing.T) {
+	writer := &nrv2bTestWriter{le32: true}
+	writer.literal('a')
+	writer.match(5, 3)
+	_, err := uclDecompress(upxMethodNRV2BLE32, writer.end(), 4)
+	if err != errUclLookbehindOverrun {
+		t.Errorf("expected lookbehind error, got %v", err)
+	}
+
+	writer = &nrv2bTestWriter{le32: true}
+	writer.literal('a')
+	writer.literal('b')
+	_, err = uclDecompress(upxMethodNRV2BLE32, writer.end(), 1)
+	if err != errUclOutputOverrun {
+		t.Errorf("expected output overrun error, got %v", err)
+	}
+
+	_, err = uclDecompress(upxMethodNRV2ELE32, []byte{0xff}, 10)
+	if err != errUclInputOverrun {
+		t.Errorf("expected input overrun error, got %v", err)
+	}
+
+	_, err = uclDecompress(upxMethodLZMA, []byte{0}, 10)
+	if err == nil {
+		t.Errorf("expected an unsupported method error for LZMA")
+	}
+}
+
+func TestUpxUnfilter(t *testing.T) {
+	// A call at offset 2 to relative target 0x10 and a call at offset 7 that wasn't converted.
+	original := []byte{0x90, 0x90, 0xe8, 0x10, 0, 0, 0, 0xe8, 0x01, 0x02, 0x03, 0x04, 0x90}
+
+	// ct32 stores absolute targets.
+	filtered := bytes.Clone(original)
+	binary.LittleEndian.PutUint32(filtered[3:], 0x10+3)
+	binary.LittleEndian.PutUint32(filtered[8:], 0x04030201+8)
+	if !upxUnfilter(0x21, 0, filtered, 0) || !bytes.Equal(filtered, original) {
+		t.Errorf("ct32 unfilter failed, got %x", filtered)
+	}
+
+	// ctok32 stores absolute targets big endian marked with the cto byte, unmarked calls are left alone.
+	filtered = bytes.Clone(original)
+	binary.BigEndian.PutUint32(filtered[3:], 0x10+3+0x2000+0xab000000)
+	if !upxUnfilter(0x46, 0xab, filtered, 0x2000) || !bytes.Equal(filtered, original) {
+		t.Errorf("ctok32 unfilter failed, got %x", filtered)
+	}
+
+	if upxUnfilter(0x50, 0, bytes.Clone(original), 0) {
+		t.Errorf("expected arm filters to be unsupported")
+	}
+}
+
+// Known answers for every supported method, each vector decompresses to the same data.
+// Offsets past 0x500 and 0xd00 exercise the NRV2D/NRV2E and NRV2B long offset paths.
+func TestUclDecompressKnownAnswers(t *testing.T) {
+	expected := []byte("UPX!")
+	match := func(offset int, length int) {
+		for i := 0; i < length; i++ {
+			expected = append(expected, expected[len(expected)-offset])
+		}
+	}
+	match(4, 8)
+	expected = append(expected, '-')
+	match(4, 3)
+	expected = append(expected, 'x')
+	match(1, 0xd20)
+	expected = append(expected, 'y')
+	match(0xd10, 5)
+	match(0xd10, 3)
+	expected = append(expected, 'z')
+	match(0x600, 4)
+	match(2, 2)
+	match(2, 7)
+
+	vectors := []struct {
+		method     uint8
+		compressed string
+	}{
+		{upxMethodNRV2B8, "f655505821031c2dd678004405477900e50f817affda014100000000000480ff"},
+		{upxMethodNRV2BLE32, "44d61cf655505821032d7800e5004705790f0041da817aff010000000000008004ff"},
+		{upxMethodNRV2D8, "f65550582107392dad780110151d79071e2c7a0afe7203084924924950ff"},
+		{upxMethodNRV2DLE32, "10ad39f655505821072d78012c071d15791e7a4908720afe0350499224ff"},
+		{upxMethodNRV2E8, "f65550582107192ded780110150d79071f1a7a05feb1023092492492a0ff"},
+		{upxMethodNRV2ELE32, "10ed19f655505821072d78011a070d15791f7a9230b105fe02a0922449ff"},
+	}
+	for _, vector := range vectors {
+		compressed, err := hex.DecodeString(vector.compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := uclDecompress(vector.method, compressed, len(expected))
+		if err != nil {
+			t.Errorf("method %d: %v", vector.method, err)
+		} else if !bytes.Equal(actual, expected) {
+			t.Errorf("method %d: expected %x got %x", vector.method, expected, actual)
+		}
+
+		// Dropping the end marker must fail rather than return partial data.
+		_, err = uclDecompress(vector.method, compressed[:len(compressed)-1], len(expected))
+		if err == nil {
+			t.Errorf("method %d: expected an error for truncated input", vector.method)
+		}
+	}
+}
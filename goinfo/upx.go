@@ -0,0 +1,435 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Magic used in the UPX pack header and the ELF l_info structure.
+var upxMagic = []byte("UPX!")
+
+// UPX executable formats that can be unpacked.
+const (
+	upxFormatWin32PE     = 9
+	upxFormatLinuxElf    = 12
+	upxFormatLinuxElfI   = 20
+	upxFormatElf64Amd    = 22
+	upxFormatElf32Arm    = 23
+	upxFormatElf32Mipsel = 30
+	upxFormatWin64PE     = 36
+	upxFormatElf64Arm    = 42
+)
+
+// Get the name of a UPX executable format as reported in the go_upx_packed feature.
+func upxFormatName(format uint8) string {
+	switch format {
+	case upxFormatWin32PE, upxFormatWin64PE:
+		return "pe"
+	case upxFormatLinuxElf, upxFormatLinuxElfI, upxFormatElf64Amd, upxFormatElf32Arm, upxFormatElf32Mipsel, upxFormatElf64Arm:
+		return "elf"
+	default:
+		return fmt.Sprintf("format_%d", format)
+	}
+}
+
+// Size of the UPX block header (b_info) that precedes each compressed block in ELF files.
+const upxBlockInfoSize = 12
+
+// Size of a PE section header.
+const peSectionHeaderSize = 40
+
+// The UPX pack header, written once per packed file.
+type upxPackHeader struct {
+	offset         int
+	version        uint8
+	format         uint8
+	method         uint8
+	level          uint8
+	uncompressed   uint32
+	compressed     uint32
+	filter         uint8
+	filterCto      uint8
+	headerSize     int
+	originalLength uint32
+}
+
+// An unpacked UPX executable.
+type upxUnpacked struct {
+	data   []byte
+	format string
+	method string
+	// Set when the code filter couldn't be reversed, metadata outside the code is still correct.
+	filterWarning string
+}
+
+// Get the size of the pack header for a UPX version.
+func upxPackHeaderSize(version uint8) int {
+	switch {
+	case version <= 3:
+		return 24
+	case version <= 9:
+		return 28
+	default:
+		return 32
+	}
+}
+
+// Parse a UPX pack header at the offset of its magic.
+func parseUpxPackHeader(data []byte, offset int) (*upxPackHeader, bool) {
+	if offset+32 > len(data) || !bytes.Equal(data[offset:offset+4], upxMagic) {
+		return nil, false
+	}
+	header := &upxPackHeader{
+		offset:         offset,
+		version:        data[offset+4],
+		format:         data[offset+5],
+		method:         data[offset+6],
+		level:          data[offset+7],
+		uncompressed:   binary.LittleEndian.Uint32(data[offset+16:]),
+		compressed:     binary.LittleEndian.Uint32(data[offset+20:]),
+		originalLength: binary.LittleEndian.Uint32(data[offset+24:]),
+		filter:         data[offset+28],
+		filterCto:      data[offset+29],
+	}
+	header.headerSize = upxPackHeaderSize(header.version)
+	// Sanity check the header so random 'UPX!' strings aren't treated as one.
+	if header.version < 10 || header.version > 14 || header.format == 0 || header.uncompressed == 0 || header.compressed == 0 || header.compressed > uint32(len(data)) {
+		return nil, false
+	}
+	return header, true
+}
+
+// Find the UPX pack header, returns nil if the file isn't UPX packed.
+func findUpxPackHeader(data []byte) *upxPackHeader {
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], upxMagic)
+		if index < 0 {
+			return nil
+		}
+		if header, ok := parseUpxPackHeader(data, offset+index); ok {
+			return header
+		}
+		offset += index + len(upxMagic)
+	}
+}
+
+// Unpack a UPX packed PE or ELF file.
+// Nil is returned without an error if the file isn't UPX packed.
+func upxUnpack(data []byte) (*upxUnpacked, error) {
+	header := findUpxPackHeader(data)
+	if header == nil {
+		return nil, nil
+	}
+	switch header.format {
+	case upxFormatWin32PE, upxFormatWin64PE:
+		return upxUnpackPE(data, header)
+	case upxFormatLinuxElf, upxFormatLinuxElfI, upxFormatElf64Amd, upxFormatElf32Arm, upxFormatElf32Mipsel, upxFormatElf64Arm:
+		return upxUnpackElf(data, header)
+	default:
+		return nil, fmt.Errorf("unsupported UPX format %d", header.format)
+	}
+}
+
+// Unpack a UPX packed PE file.
+// The compressed data holds the original image laid out by virtual address followed by the original
+// PE header and section headers, which are used to rebuild a PE file with the original sections.
+// Imports and relocations are not rebuilt as they are not needed to read the Go metadata.
+func upxUnpackPE(data []byte, header *upxPackHeader) (*upxUnpacked, error) {
+	compressedStart := header.offset + header.headerSize
+	compressedEnd := compressedStart + int(header.compressed)
+	if compressedEnd > len(data) {
+		return nil, errors.New("UPX compressed data extends past the end of the file")
+	}
+	image, err := uclDecompress(header.method, data[compressedStart:compressedEnd], int(header.uncompressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress UPX data: %w", err)
+	}
+	if len(image) < 4 {
+		return nil, errors.New("UPX image is too small")
+	}
+
+	// The last 4 bytes hold the offset of the original PE header in the image.
+	originalHeaderOffset := int(binary.LittleEndian.Uint32(image[len(image)-4:]))
+	if originalHeaderOffset+24 > len(image) || !bytes.Equal(image[originalHeaderOffset:originalHeaderOffset+4], []byte("PE\x00\x00")) {
+		return nil, errors.New("original PE header not found in UPX image")
+	}
+	sectionCount := int(binary.LittleEndian.Uint16(image[originalHeaderOffset+6:]))
+	optionalHeaderSize := int(binary.LittleEndian.Uint16(image[originalHeaderOffset+20:]))
+	peHeaderSize := 24 + optionalHeaderSize
+	sectionHeadersStart := originalHeaderOffset + peHeaderSize
+	sectionHeadersEnd := sectionHeadersStart + sectionCount*peSectionHeaderSize
+	if optionalHeaderSize < 96 || sectionHeadersEnd > len(image) {
+		return nil, errors.New("original PE section headers not found in UPX image")
+	}
+	peHeader := bytes.Clone(image[originalHeaderOffset:sectionHeadersStart])
+	sectionHeaders := bytes.Clone(image[sectionHeadersStart:sectionHeadersEnd])
+
+	optionalHeader := peHeader[24:]
+	fileAlignment := binary.LittleEndian.Uint32(optionalHeader[36:])
+	if fileAlignment == 0 || fileAlignment&(fileAlignment-1) != 0 {
+		fileAlignment = 0x200
+	}
+	codeBase := binary.LittleEndian.Uint32(optionalHeader[20:])
+	codeSize := binary.LittleEndian.Uint32(optionalHeader[4:])
+
+	// The image starts at the virtual address of the first section.
+	rvaMin := binary.LittleEndian.Uint32(sectionHeaders[12:])
+	unpacked := &upxUnpacked{format: upxFormatName(header.format), method: upxMethodName(header.method)}
+	if header.filter != 0 && codeBase >= rvaMin && uint64(codeBase-rvaMin)+uint64(codeSize) <= uint64(len(image)) {
+		code := image[codeBase-rvaMin : codeBase-rvaMin+codeSize]
+		if !upxUnfilter(header.filter, header.filterCto, code, codeBase-rvaMin) {
+			unpacked.filterWarning = fmt.Sprintf("unsupported UPX filter 0x%02x", header.filter)
+		}
+	}
+
+	// Rebuild the file as DOS header, PE header, section headers then each section's raw data.
+	alignUp := func(value uint32) uint32 { return (value + fileAlignment - 1) &^ (fileAlignment - 1) }
+	dosHeader := make([]byte, 64)
+	copy(dosHeader, data[:min(64, len(data))])
+	binary.LittleEndian.PutUint32(dosHeader[0x3c:], 64)
+	out := append(dosHeader, peHeader...)
+	out = append(out, sectionHeaders...)
+	headersSize := alignUp(uint32(len(out)))
+	binary.LittleEndian.PutUint32(optionalHeader[60:], headersSize)
+	copy(out[64:], peHeader)
+	out = append(out, make([]byte, int(headersSize)-len(out))...)
+
+	for i := 0; i < sectionCount; i++ {
+		// Index into the output each time as appending the section data may reallocate it.
+		sectionHeaderOffset := 64 + peHeaderSize + i*peSectionHeaderSize
+		virtualAddress := binary.LittleEndian.Uint32(out[sectionHeaderOffset+12:])
+		rawSize := binary.LittleEndian.Uint32(out[sectionHeaderOffset+16:])
+		var sectionData []byte
+		if virtualAddress >= rvaMin && virtualAddress-rvaMin < uint32(originalHeaderOffset) {
+			start := virtualAddress - rvaMin
+			end := min(uint64(start)+uint64(rawSize), uint64(originalHeaderOffset))
+			sectionData = image[start:end]
+		}
+		rawPointer := uint32(0)
+		if len(sectionData) > 0 {
+			rawPointer = uint32(len(out))
+			out = append(out, sectionData...)
+			out = append(out, make([]byte, int(alignUp(uint32(len(out))))-len(out))...)
+		}
+		binary.LittleEndian.PutUint32(out[sectionHeaderOffset+16:], alignUp(uint32(len(sectionData))))
+		binary.LittleEndian.PutUint32(out[sectionHeaderOffset+20:], rawPointer)
+	}
+	// The COFF symbol table isn't kept by UPX.
+	err = removePESymbolTable(out)
+	if err != nil {
+		return nil, err
+	}
+	unpacked.data = out
+	return unpacked, nil
+}
+
+// Reads the sequence of UPX compressed blocks in an ELF file.
+type upxElfBlockReader struct {
+	data     []byte
+	offset   int
+	unpacked *upxUnpacked
+}
+
+// Decompress blocks until size bytes of output have been produced.
+func (br *upxElfBlockReader) extent(size uint64, executable bool) ([]byte, error) {
+	out := []byte{}
+	for uint64(len(out)) < size {
+		if br.offset+upxBlockInfoSize > len(br.data) {
+			return nil, errors.New("UPX block header extends past the end of the file")
+		}
+		uncompressedSize := binary.LittleEndian.Uint32(br.data[br.offset:])
+		compressedSize := binary.LittleEndian.Uint32(br.data[br.offset+4:])
+		method := br.data[br.offset+8]
+		filterID := br.data[br.offset+9]
+		filterCto := br.data[br.offset+10]
+		br.offset += upxBlockInfoSize
+		if uncompressedSize == 0 {
+			return nil, errors.New("UPX data ended before the extent was complete")
+		}
+		if compressedSize > uncompressedSize || br.offset+int(compressedSize) > len(br.data) || uint64(len(out))+uint64(uncompressedSize) > size {
+			return nil, errors.New("invalid UPX block header")
+		}
+		compressed := br.data[br.offset : br.offset+int(compressedSize)]
+		br.offset += int(compressedSize)
+		var block []byte
+		// Blocks that don't compress are stored as is.
+		if compressedSize == uncompressedSize {
+			block = bytes.Clone(compressed)
+		} else {
+			var err error
+			block, err = uclDecompress(method, compressed, int(uncompressedSize))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress UPX block: %w", err)
+			}
+			br.unpacked.method = upxMethodName(method)
+		}
+		if filterID != 0 && executable {
+			if !upxUnfilter(filterID, filterCto, block, 0) {
+				br.unpacked.filterWarning = fmt.Sprintf("unsupported UPX filter 0x%02x", filterID)
+			}
+		}
+		out = append(out, block...)
+	}
+	return out, nil
+}
+
+// Get a copy of the original ELF header and program headers that can be parsed on its own.
+// The section headers are at the end of the original file, so they are removed from the copy.
+func upxElfProgramHeaders(elfHeaders []byte) []byte {
+	headers := append(bytes.Clone(elfHeaders), make([]byte, 64)...)
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if headers[elf.EI_DATA] == byte(elf.ELFDATA2MSB) {
+		byteOrder = binary.BigEndian
+	}
+	if headers[elf.EI_CLASS] == byte(elf.ELFCLASS64) {
+		byteOrder.PutUint64(headers[0x28:], 0)
+		byteOrder.PutUint32(headers[0x3c:], 0)
+	} else {
+		byteOrder.PutUint32(headers[0x20:], 0)
+		byteOrder.PutUint32(headers[0x30:], 0)
+	}
+	return headers
+}
+
+// Unpack a UPX packed ELF file.
+// The compressed blocks follow the l_info and p_info headers, the first block starts with the original
+// ELF header and program headers and the blocks hold each PT_LOAD segment in order followed by
+// the gaps between them and the rest of the original file.
+func upxUnpackElf(data []byte, header *upxPackHeader) (*upxUnpacked, error) {
+	// l_info is 12 bytes with the magic at offset 4, it is the first 'UPX!' in the file.
+	lInfoMagic := bytes.Index(data, upxMagic)
+	if lInfoMagic < 4 || lInfoMagic == header.offset {
+		return nil, errors.New("UPX l_info header not found")
+	}
+	pInfo := lInfoMagic - 4 + 12
+	if pInfo+12 > len(data) {
+		return nil, errors.New("UPX p_info header not found")
+	}
+	originalSize := uint64(binary.LittleEndian.Uint32(data[pInfo+4:]))
+	if originalSize == 0 || originalSize > 1<<31 {
+		return nil, errors.New("invalid original file size in UPX p_info header")
+	}
+
+	unpacked := &upxUnpacked{format: upxFormatName(header.format)}
+	reader := &upxElfBlockReader{data: data, offset: pInfo + 12, unpacked: unpacked}
+	// Peek at the first block to get the original ELF and program headers.
+	firstBlockOffset := reader.offset
+	if firstBlockOffset+upxBlockInfoSize > len(data) {
+		return nil, errors.New("UPX block header extends past the end of the file")
+	}
+	firstBlockSize := uint64(binary.LittleEndian.Uint32(data[firstBlockOffset:]))
+	elfHeaders, err := reader.extent(firstBlockSize, false)
+	if err != nil {
+		return nil, err
+	}
+	reader.offset = firstBlockOffset
+	originalElf, err := elf.NewFile(bytes.NewReader(upxElfProgramHeaders(elfHeaders)))
+	if err != nil {
+		return nil, fmt.Errorf("original ELF header in UPX data is invalid: %w", err)
+	}
+
+	loads := []*elf.Prog{}
+	for _, prog := range originalElf.Progs {
+		if prog.Type == elf.PT_LOAD {
+			loads = append(loads, prog)
+		}
+	}
+	if len(loads) == 0 {
+		return nil, errors.New("original ELF in UPX data has no PT_LOAD segments")
+	}
+
+	out := make([]byte, originalSize)
+	write := func(offset uint64, extentData []byte) error {
+		if offset+uint64(len(extentData)) > originalSize {
+			return errors.New("UPX extent extends past the original file size")
+		}
+		copy(out[offset:], extentData)
+		return nil
+	}
+	for _, load := range loads {
+		extentData, err := reader.extent(load.Filesz, load.Flags&elf.PF_X != 0)
+		if err != nil {
+			return nil, err
+		}
+		err = write(load.Off, extentData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The gaps between the segments and everything after the last segment are compressed last, in file order.
+	sort.Slice(loads, func(i, j int) bool { return loads[i].Off < loads[j].Off })
+	for i, load := range loads {
+		gapStart := load.Off + load.Filesz
+		gapEnd := originalSize
+		if i+1 < len(loads) {
+			gapEnd = loads[i+1].Off
+		}
+		if gapEnd <= gapStart {
+			continue
+		}
+		extentData, err := reader.extent(gapEnd-gapStart, false)
+		if err != nil {
+			// Older UPX versions don't store the data after the last segment.
+			if i+1 == len(loads) {
+				break
+			}
+			return nil, err
+		}
+		err = write(gapStart, extentData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	unpacked.data = out
+	return unpacked, nil
+}
+
+// Unpack the file if it is UPX packed, adding the unpacked executable to the report.
+// The path to a temporary copy of the unpacked executable is returned, or an empty string if the
+// file isn't UPX packed or couldn't be unpacked. The caller must remove the temporary file.
+func (gi *Analyzer) unpackUpx(features *featureWriter, contentFilePath string) (string, error) {
+	data, err := os.ReadFile(contentFilePath)
+	if err != nil {
+		return "", newError("Could not be read", "Failed to read the file to check for UPX", err)
+	}
+	unpacked, err := upxUnpack(data)
+	methodErr := &uclMethodError{}
+	if errors.As(err, &methodErr) {
+		// The file is known to be packed, but only the packed file can be analysed.
+		header := findUpxPackHeader(data)
+		features.addFeatureWithExtra("go_upx_packed", upxFormatName(header.format), &featureOptions{Label: "unsupported method " + upxMethodName(methodErr.method)})
+		return "", nil
+	}
+	if err != nil {
+		// Carry on with the packed file, it may still have some Go metadata.
+		features.addFeature("go_upx_warning", fmt.Sprintf("UPX unpacking failed: %s", err.Error()))
+		return "", nil
+	}
+	if unpacked == nil {
+		return "", nil
+	}
+
+	features.addFeatureWithExtra("go_upx_packed", unpacked.format, &featureOptions{Label: unpacked.method})
+	if unpacked.filterWarning != "" {
+		features.addFeature("go_upx_warning", unpacked.filterWarning)
+	}
+	features.result.Unpacked = unpacked.data
+
+	unpackedFile, err := os.CreateTemp("", "goinfo-upx-unpacked-")
+	if err != nil {
+		return "", newError("Temporary file failed", "Failed to create a file for the UPX unpacked executable", err)
+	}
+	defer unpackedFile.Close()
+	_, err = unpackedFile.Write(unpacked.data)
+	if err != nil {
+		os.Remove(unpackedFile.Name())
+		return "", newError("Temporary file failed", "Failed to write the UPX unpacked executable", err)
+	}
+	return unpackedFile.Name(), nil
+}
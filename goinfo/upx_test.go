@@ -0,0 +1,277 @@
+package goinfo
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Build a UPX pack header.
+func upxTestPackHeader(format uint8, method uint8, uncompressed int, compressed int) []byte {
+	header := make([]byte, 32)
+	copy(header, upxMagic)
+	header[4] = 13
+	header[5] = format
+	header[6] = method
+	header[7] = 9
+	binary.LittleEndian.PutUint32(header[16:], uint32(uncompressed))
+	binary.LittleEndian.PutUint32(header[20:], uint32(compressed))
+	binary.LittleEndian.PutUint32(header[24:], uint32(uncompressed))
+	return header
+}
+
+// Pack an ELF file the way UPX lays it out, with each extent split into compressed or stored blocks.
+func upxTestPackElf(t testing.TB, original []byte) []byte {
+	elfFile, err := elf.NewFile(bytes.NewReader(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loads := []*elf.Prog{}
+	for _, prog := range elfFile.Progs {
+		if prog.Type == elf.PT_LOAD {
+			loads = append(loads, prog)
+		}
+	}
+
+	// Stand in for the UPX loader stub, then the l_info and p_info headers.
+	packed := make([]byte, 0x100)
+	lInfo := make([]byte, 12)
+	copy(lInfo[4:], upxMagic)
+	binary.LittleEndian.PutUint16(lInfo[8:], 0x300)
+	lInfo[10] = 13
+	lInfo[11] = upxFormatElf64Amd
+	packed = append(packed, lInfo...)
+	pInfo := make([]byte, 12)
+	binary.LittleEndian.PutUint32(pInfo[4:], uint32(len(original)))
+	binary.LittleEndian.PutUint32(pInfo[8:], 0x40000)
+	packed = append(packed, pInfo...)
+
+	addBlock := func(block []byte) {
+		compressed := nrv2bTestCompress(block, true)
+		if len(compressed) >= len(block) {
+			compressed = block
+		}
+		blockInfo := make([]byte, upxBlockInfoSize)
+		binary.LittleEndian.PutUint32(blockInfo[0:], uint32(len(block)))
+		binary.LittleEndian.PutUint32(blockInfo[4:], uint32(len(compressed)))
+		blockInfo[8] = upxMethodNRV2BLE32
+		packed = append(packed, blockInfo...)
+		packed = append(packed, compressed...)
+	}
+	addExtent := func(extent []byte, firstBlockSize int) {
+		for len(extent) > 0 {
+			size := min(len(extent), 0x40000)
+			if firstBlockSize > 0 {
+				size = firstBlockSize
+				firstBlockSize = 0
+			}
+			addBlock(extent[:size])
+			extent = extent[size:]
+		}
+	}
+
+	// The first block only holds the ELF and program headers.
+	headerSize := 64 + len(elfFile.Progs)*56
+	for i, load := range loads {
+		firstBlockSize := 0
+		if i == 0 {
+			firstBlockSize = headerSize
+		}
+		addExtent(original[load.Off:load.Off+load.Filesz], firstBlockSize)
+	}
+	sort.Slice(loads, func(i, j int) bool { return loads[i].Off < loads[j].Off })
+	for i, load := range loads {
+		gapEnd := uint64(len(original))
+		if i+1 < len(loads) {
+			gapEnd = loads[i+1].Off
+		}
+		if gapEnd > load.Off+load.Filesz {
+			addExtent(original[load.Off+load.Filesz:gapEnd], 0)
+		}
+	}
+	packed = append(packed, make([]byte, upxBlockInfoSize)...)
+	return append(packed, upxTestPackHeader(upxFormatElf64Amd, upxMethodNRV2BLE32, len(original), len(packed))...)
+}
+
+// Pack a PE file the way UPX lays it out, the sections are placed by virtual address then
+// followed by the original PE and section headers and the offset to them.
+func upxTestPackPE(t testing.TB, original []byte) []byte {
+	peFile, err := pe.NewFile(bytes.NewReader(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rvaMin := peFile.Sections[0].VirtualAddress
+	image := []byte{}
+	for _, section := range peFile.Sections {
+		data, err := section.Data()
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := int(section.VirtualAddress - rvaMin)
+		end := start + int(max(section.VirtualSize, section.Size))
+		if end > len(image) {
+			image = append(image, make([]byte, end-len(image))...)
+		}
+		copy(image[start:], data)
+	}
+	peHeaderOffset := int(binary.LittleEndian.Uint32(original[0x3c:]))
+	optionalHeaderSize := int(binary.LittleEndian.Uint16(original[peHeaderOffset+20:]))
+	headersEnd := peHeaderOffset + 24 + optionalHeaderSize + len(peFile.Sections)*peSectionHeaderSize
+	originalHeaderOffset := len(image)
+	image = append(image, original[peHeaderOffset:headersEnd]...)
+	image = binary.LittleEndian.AppendUint32(image, uint32(originalHeaderOffset))
+
+	compressed := nrv2bTestCompress(image, true)
+	packed := bytes.Clone(original[:0x40])
+	packed = append(packed, make([]byte, 0x3c0)...)
+	packed = append(packed, upxTestPackHeader(upxFormatWin64PE, upxMethodNRV2BLE32, len(image), len(compressed))...)
+	return append(packed, compressed...)
+}
+
+// Check the unpacked executable has the original Go build information.
+func checkUpxUnpackedBuildInfo(t *testing.T, original string, unpacked []byte) {
+	expected, err := buildinfo.ReadFile(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpackedPath := filepath.Join(t.TempDir(), "unpacked.bin")
+	err = os.WriteFile(unpackedPath, unpacked, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := buildinfo.ReadFile(unpackedPath)
+	if err != nil {
+		t.Fatalf("unpacked file has no build info: %v", err)
+	}
+	if actual.GoVersion != expected.GoVersion || actual.Path != expected.Path {
+		t.Errorf("expected build info %s %s got %s %s", expected.GoVersion, expected.Path, actual.GoVersion, actual.Path)
+	}
+}
+
+func TestUpxUnpackElf(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=linux", "GOARCH=amd64"})
+	original, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, err := upxUnpack(upxTestPackElf(t, original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpacked == nil || unpacked.format != "elf" || unpacked.method != "nrv2b" {
+		t.Fatalf("expected an nrv2b packed elf, got %+v", unpacked)
+	}
+	if !bytes.Equal(unpacked.data, original) {
+		t.Errorf("unpacked ELF does not match the original")
+	}
+}
+
+func TestUpxUnpackPE(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=windows", "GOARCH=amd64"})
+	original, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, err := upxUnpack(upxTestPackPE(t, original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpacked == nil || unpacked.format != "pe" {
+		t.Fatalf("expected a packed pe, got %+v", unpacked)
+	}
+	peFile, err := pe.NewFile(bytes.NewReader(unpacked.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalPE, err := pe.NewFile(bytes.NewReader(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, originalSection := range originalPE.Sections {
+		// Sections with long names are renamed as the string table is not kept.
+		if len(originalSection.Name) > 8 {
+			continue
+		}
+		section := peFile.Section(originalSection.Name)
+		if section == nil {
+			t.Errorf("section %s missing from unpacked PE", originalSection.Name)
+			continue
+		}
+		expected, _ := originalSection.Data()
+		actual, _ := section.Data()
+		if !bytes.HasPrefix(actual, expected) {
+			t.Errorf("section %s does not match the original", originalSection.Name)
+		}
+	}
+	checkUpxUnpackedBuildInfo(t, binaryPath, unpacked.data)
+}
+
+func TestUpxUnpackLocalPacker(t *testing.T) {
+	upxPath, err := exec.LookPath("upx")
+	if err != nil {
+		t.Skip("upx is not installed")
+	}
+	for _, goos := range []string{"linux", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=" + goos, "GOARCH=amd64"})
+			packedPath := filepath.Join(t.TempDir(), "packed.bin")
+			output, err := exec.Command(upxPath, "-q", "-o", packedPath, binaryPath).CombinedOutput()
+			if err != nil {
+				t.Skipf("upx failed to pack the test program: %v %s", err, output)
+			}
+			packed, err := os.ReadFile(packedPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			unpacked, err := upxUnpack(packed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if unpacked == nil {
+				t.Fatal("expected the file to be detected as UPX packed")
+			}
+			checkUpxUnpackedBuildInfo(t, binaryPath, unpacked.data)
+		})
+	}
+}
+
+func TestUpxUnpackNotPacked(t *testing.T) {
+	unpacked, err := upxUnpack([]byte("MZ not packed but mentions UPX! in a string"))
+	if unpacked != nil || err != nil {
+		t.Errorf("expected nothing to be unpacked, got %v %v", unpacked, err)
+	}
+}
+
+func TestUpxUnpackUnsupportedMethod(t *testing.T) {
+	binaryPath := buildTestProgram(t, helloWorldSource, []string{"GOOS=windows", "GOARCH=amd64"})
+	original, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed := upxTestPackPE(t, original)
+	header := findUpxPackHeader(packed)
+	packed[header.offset+6] = upxMethodLZMA
+	packedPath := filepath.Join(t.TempDir(), "packed.exe")
+	err = os.WriteFile(packedPath, packed, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &Report{}
+	unpackedPath, err := NewAnalyzer(nil).unpackUpx(&featureWriter{result: result}, packedPath)
+	if err != nil || unpackedPath != "" {
+		t.Fatalf("expected the packed file to be analysed as is, got %q %v", unpackedPath, err)
+	}
+	expected := []Feature{{Name: "go_upx_packed", Value: "pe", Label: "unsupported method lzma"}}
+	if !reflect.DeepEqual(result.Features, expected) {
+		t.Errorf("expected %+v got %+v", expected, result.Features)
+	}
+}
@@ -0,0 +1,83 @@
+package goinfo
+
+import (
+	"strings"
+
+	"github.com/goretk/gore"
+)
+
+// Package path prefixes from the settings that decide whether a package is user or library code,
+// for vendored forks and renamed standard library copies that gore or the build info get wrong.
+// A prefix matches the package with that path and every package below it.
+type packagePrefixes struct {
+	user    []string
+	library []string
+}
+
+// Split a comma separated list of package path prefixes.
+func parsePackagePrefixes(value string) []string {
+	prefixes := []string{}
+	for _, prefix := range strings.Split(value, ",") {
+		prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+		if prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// Length of the longest prefix matching the package path, zero when none match.
+func longestPackagePrefix(prefixes []string, packagePath string) int {
+	longest := 0
+	for _, prefix := range prefixes {
+		if (packagePath == prefix || strings.HasPrefix(packagePath, prefix+"/")) && len(prefix) > longest {
+			longest = len(prefix)
+		}
+	}
+	return longest
+}
+
+// Check whether the settings make a package user code. matched is false when no prefix applies
+// and the usual classification should be used. The longest matching prefix wins when both lists match.
+func (pp *packagePrefixes) classify(packagePath string) (user bool, matched bool) {
+	if pp == nil {
+		return false, false
+	}
+	packagePath = strings.TrimPrefix(packagePath, "vendor/")
+	userLength := longestPackagePrefix(pp.user, packagePath)
+	libraryLength := longestPackagePrefix(pp.library, packagePath)
+	if userLength == 0 && libraryLength == 0 {
+		return false, false
+	}
+	return userLength > libraryLength, true
+}
+
+// Move the packages gore found between the user and library lists according to the prefixes.
+// Other packages, such as the standard library, are only moved into the user list.
+func (pp *packagePrefixes) reclassify(userPackages []*gore.Package, libraryPackages []*gore.Package, otherPackages []*gore.Package) ([]*gore.Package, []*gore.Package) {
+	if pp == nil {
+		return userPackages, libraryPackages
+	}
+	reclassifiedUser := []*gore.Package{}
+	reclassifiedLibrary := []*gore.Package{}
+	for _, pkg := range userPackages {
+		if user, matched := pp.classify(pkg.Name); matched && !user {
+			reclassifiedLibrary = append(reclassifiedLibrary, pkg)
+		} else {
+			reclassifiedUser = append(reclassifiedUser, pkg)
+		}
+	}
+	for _, pkg := range libraryPackages {
+		if user, matched := pp.classify(pkg.Name); matched && user {
+			reclassifiedUser = append(reclassifiedUser, pkg)
+		} else {
+			reclassifiedLibrary = append(reclassifiedLibrary, pkg)
+		}
+	}
+	for _, pkg := range otherPackages {
+		if user, matched := pp.classify(pkg.Name); matched && user {
+			reclassifiedUser = append(reclassifiedUser, pkg)
+		}
+	}
+	return reclassifiedUser, reclassifiedLibrary
+}
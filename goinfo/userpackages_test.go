@@ -0,0 +1,81 @@
+package goinfo
+
+import (
+	"testing"
+
+	"github.com/goretk/gore"
+)
+
+func TestPackagePrefixes(t *testing.T) {
+	prefixes := &packagePrefixes{
+		user:    parsePackagePrefixes(" github.com/evil/fork/ , crypto/aes"),
+		library: parsePackagePrefixes("github.com/evil,,github.com/evil/fork/vendored"),
+	}
+	testCases := []struct {
+		packagePath string
+		user        bool
+		matched     bool
+	}{
+		{"github.com/evil/fork", true, true},
+		{"vendor/github.com/evil/fork/cmd", true, true},
+		{"github.com/evil/fork/vendored/lib", false, true},
+		{"github.com/evil/tool", false, true},
+		{"github.com/evilcorp/tool", false, false},
+		{"crypto/aes", true, true},
+		{"crypto/aesgcm", false, false},
+		{"main", false, false},
+	}
+	for _, tc := range testCases {
+		user, matched := prefixes.classify(tc.packagePath)
+		if user != tc.user || matched != tc.matched {
+			t.Errorf("expected %s to classify as user %v matched %v, got %v %v", tc.packagePath, tc.user, tc.matched, user, matched)
+		}
+	}
+	var noPrefixes *packagePrefixes
+	if _, matched := noPrefixes.classify("main"); matched {
+		t.Errorf("expected no match without settings")
+	}
+
+	userPackages, libraryPackages := prefixes.reclassify(
+		[]*gore.Package{{Name: "main"}, {Name: "github.com/evil/tool"}},
+		[]*gore.Package{{Name: "github.com/evil/fork/cmd"}, {Name: "golang.org/x/sys/unix"}},
+		[]*gore.Package{{Name: "crypto/aes"}, {Name: "net/http"}},
+	)
+	names := func(packages []*gore.Package) []string {
+		packageNames := []string{}
+		for _, pkg := range packages {
+			packageNames = append(packageNames, pkg.Name)
+		}
+		return packageNames
+	}
+	if got := names(userPackages); len(got) != 3 || got[0] != "main" || got[1] != "github.com/evil/fork/cmd" || got[2] != "crypto/aes" {
+		t.Errorf("unexpected user packages %v", got)
+	}
+	if got := names(libraryPackages); len(got) != 2 || got[0] != "github.com/evil/tool" || got[1] != "golang.org/x/sys/unix" {
+		t.Errorf("unexpected library packages %v", got)
+	}
+}
+
+func TestPackageClassifierPrefixes(t *testing.T) {
+	settings, err := LoadSettings([]string{
+		"PLUGIN_GOINFO_USER_PACKAGES=github.com/spf13/cobra/internal",
+		"PLUGIN_GOINFO_LIBRARY_PACKAGES=github.com/evil/implant/third_party",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	classifier := &packageClassifier{
+		mainModule:   "github.com/evil/implant",
+		dependencies: []string{"github.com/spf13/cobra"},
+		prefixes:     settings.userCodePrefixes(),
+	}
+	if !classifier.isUser("github.com/spf13/cobra/internal/x") || classifier.isVendor("github.com/spf13/cobra/internal/x") {
+		t.Errorf("expected the user prefix to win over the dependency")
+	}
+	if classifier.isUser("github.com/evil/implant/third_party/zip") || !classifier.isVendor("github.com/evil/implant/third_party/zip") {
+		t.Errorf("expected the library prefix to win over the main module")
+	}
+	if !classifier.isUser("github.com/evil/implant/beacon") || !classifier.isVendor("github.com/spf13/cobra") {
+		t.Errorf("expected packages outside the prefixes to be classified as before")
+	}
+}
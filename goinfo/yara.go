@@ -0,0 +1,215 @@
+package goinfo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Maximum number of strings placed into a generated YARA rule.
+const yaraMaxStrings = 20
+
+// Minimum number of candidate strings required before a rule is generated.
+const yaraMinStrings = 3
+
+// Strings shorter than this are too likely to appear in unrelated files.
+const yaraMinStringLength = 6
+
+// Matches compiler generated suffixes (closures, goroutine wrappers, etc.) that are shared by most Go binaries.
+var yaraGeneratedNameRegex = regexp.MustCompile(`\.(func|gowrap|deferwrap)\d+(\.\d+)*$|\.init\.\d+$`)
+
+// Matches characters that are not permitted in a YARA rule identifier.
+var yaraIdentifierRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Names that appear in almost every Go binary and are useless for hunting.
+var yaraCommonNames = map[string]struct{}{
+	"main":      {},
+	"main.main": {},
+	"main.init": {},
+	"init":      {},
+	"String":    {},
+	"Error":     {},
+	"Close":     {},
+	"Read":      {},
+	"Write":     {},
+	"Run":       {},
+	"Start":     {},
+	"Stop":      {},
+	"Len":       {},
+	"Less":      {},
+	"Swap":      {},
+}
+
+// Base weight given to each kind of candidate string, the more distinctive a kind the higher the weight.
+var yaraKindWeights = map[string]int{
+	"module":   100,
+	"build_id": 90,
+	"package":  60,
+	"function": 40,
+	"type":     30,
+}
+
+// A string that may be used to build a YARA rule for a Go binary.
+type yaraCandidate struct {
+	kind   string
+	value  string
+	weight int
+}
+
+// Go artefacts collected during analysis that are used to build a YARA rule.
+type yaraSource struct {
+	mainModule string
+	buildID    string
+	goVersion  string
+	packages   []string
+	functions  []string
+	types      []string
+	// Packages that belong to 3rd party libraries, strings from these are never used.
+	libraryPackages map[string]struct{}
+}
+
+// Returns true if the package path belongs to a library rather than the binary's own code.
+func (ys *yaraSource) isLibraryName(value string) bool {
+	for libraryPackage := range ys.libraryPackages {
+		if value == libraryPackage || strings.HasPrefix(value, libraryPackage+".") || strings.HasPrefix(value, libraryPackage+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the value is generic enough that it shouldn't be used in a rule.
+func isCommonYaraName(value string) bool {
+	if len(value) < yaraMinStringLength {
+		return true
+	}
+	if _, ok := yaraCommonNames[value]; ok {
+		return true
+	}
+	return yaraGeneratedNameRegex.MatchString(value)
+}
+
+// Collect and weight all candidate strings for a YARA rule, leaving out common and library names.
+func (ys *yaraSource) candidates() []yaraCandidate {
+	seen := map[string]struct{}{}
+	candidates := []yaraCandidate{}
+	add := func(kind string, value string) {
+		if isCommonYaraName(value) || ys.isLibraryName(value) {
+			return
+		}
+		if _, ok := seen[value]; ok {
+			return
+		}
+		seen[value] = struct{}{}
+		// Longer strings are less likely to cause false positives, but cap the bonus so a long
+		// generic value can't outweigh a more distinctive kind.
+		lengthBonus := min(len(value), 40)
+		candidates = append(candidates, yaraCandidate{kind: kind, value: value, weight: yaraKindWeights[kind] + lengthBonus})
+	}
+
+	// Only the main module path is useful, modules named after a single word are too generic.
+	if strings.Contains(ys.mainModule, "/") || strings.Contains(ys.mainModule, ".") {
+		add("module", ys.mainModule)
+	}
+	// Go build IDs are made of up to 4 slash separated hashes, each is unique to the build.
+	for _, fragment := range strings.Split(ys.buildID, "/") {
+		if len(fragment) >= 16 {
+			add("build_id", fragment)
+		}
+	}
+	for _, pkg := range ys.packages {
+		add("package", pkg)
+	}
+	for _, function := range ys.functions {
+		add("function", function)
+	}
+	for _, goType := range ys.types {
+		add("type", goType)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].weight != candidates[j].weight {
+			return candidates[i].weight > candidates[j].weight
+		}
+		return candidates[i].value < candidates[j].value
+	})
+	return candidates
+}
+
+// Escape a string so it can be used as a YARA text string.
+func escapeYaraString(value string) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&sb, "\\x%02x", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// Build a candidate YARA rule from the distinctive artefacts of a Go binary.
+// An empty string is returned if there aren't enough distinctive strings to make a useful rule.
+func buildYaraRule(ruleName string, source *yaraSource) string {
+	candidates := source.candidates()
+	if len(candidates) < yaraMinStrings {
+		return ""
+	}
+	if len(candidates) > yaraMaxStrings {
+		candidates = candidates[:yaraMaxStrings]
+	}
+
+	ruleName = yaraIdentifierRegex.ReplaceAllString(ruleName, "_")
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "rule %s\n{\n", ruleName)
+	sb.WriteString("    meta:\n")
+	sb.WriteString("        description = \"Candidate rule generated from distinctive Go artefacts, review before use\"\n")
+	if source.goVersion != "" {
+		fmt.Fprintf(&sb, "        go_version = \"%s\"\n", escapeYaraString(source.goVersion))
+	}
+	if source.mainModule != "" {
+		fmt.Fprintf(&sb, "        go_module = \"%s\"\n", escapeYaraString(source.mainModule))
+	}
+	sb.WriteString("    strings:\n")
+	hasBuildID := false
+	otherCount := 0
+	kindCounts := map[string]int{}
+	for _, candidate := range candidates {
+		kindCounts[candidate.kind]++
+		if candidate.kind == "build_id" {
+			hasBuildID = true
+		} else {
+			otherCount++
+		}
+		fmt.Fprintf(&sb, "        $%s_%d = \"%s\" ascii\n", candidate.kind, kindCounts[candidate.kind], escapeYaraString(candidate.value))
+	}
+	sb.WriteString("    condition:\n")
+	sb.WriteString("        (uint16(0) == 0x5a4d or uint32(0) == 0x464c457f or uint32(0) == 0xfeedface or uint32(0) == 0xfeedfacf or\n")
+	sb.WriteString("         uint32(0) == 0xcefaedfe or uint32(0) == 0xcffaedfe or uint32(0) == 0xcafebabe or uint32(0) == 0xbebafeca) and\n")
+
+	// Require about half of the non build ID strings so small code changes between builds still match.
+	required := max((otherCount+1)/2, 1)
+	nonBuildIDSets := []string{}
+	for _, kind := range []string{"module", "package", "function", "type"} {
+		if kindCounts[kind] > 0 {
+			nonBuildIDSets = append(nonBuildIDSets, fmt.Sprintf("$%s_*", kind))
+		}
+	}
+	switch {
+	case hasBuildID && otherCount > 0:
+		fmt.Fprintf(&sb, "        (any of ($build_id_*) or %d of (%s))\n", required, strings.Join(nonBuildIDSets, ", "))
+	case hasBuildID:
+		sb.WriteString("        any of ($build_id_*)\n")
+	default:
+		fmt.Fprintf(&sb, "        %d of (%s)\n", required, strings.Join(nonBuildIDSets, ", "))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package goinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildYaraRule(t *testing.T) {
+	rule := buildYaraRule("goinfo_test/build", &yaraSource{
+		mainModule: "github.com/evil/implant",
+		buildID:    "THyJCsrrxDurShR_lQa-/NLfIvSXE3EVr4GaK6lHd/ym0JcumGoh8PSe7LgwLL/t31lXGfMUAPhGc-8ehfM",
+		goVersion:  "go1.15",
+		packages:   []string{"main", "github.com/evil/implant/beacon", "github.com/spf13/cobra"},
+		functions: []string{
+			"main.main",
+			"main.main.func1",
+			"github.com/evil/implant/beacon.sendHeartbeat",
+			"github.com/spf13/cobra.(*Command).Execute",
+		},
+		types: []string{"beacon.Config"},
+		libraryPackages: map[string]struct{}{
+			"github.com/spf13/cobra": {},
+		},
+	})
+
+	if !strings.HasPrefix(rule, "rule goinfo_test_build\n") {
+		t.Errorf("rule name was not sanitised, got rule:\n%s", rule)
+	}
+	for _, expected := range []string{
+		`$module_1 = "github.com/evil/implant" ascii`,
+		`$build_id_1 = "NLfIvSXE3EVr4GaK6lHd" ascii`,
+		`"github.com/evil/implant/beacon.sendHeartbeat" ascii`,
+		`"beacon.Config" ascii`,
+		`any of ($build_id_*)`,
+	} {
+		if !strings.Contains(rule, expected) {
+			t.Errorf("expected rule to contain %q, got rule:\n%s", expected, rule)
+		}
+	}
+	for _, unexpected := range []string{
+		`"main.main"`,
+		`"main.main.func1"`,
+		`"github.com/spf13/cobra"`,
+		`"github.com/spf13/cobra.(*Command).Execute"`,
+	} {
+		if strings.Contains(rule, unexpected) {
+			t.Errorf("expected rule to not contain common or library name %s, got rule:\n%s", unexpected, rule)
+		}
+	}
+}
+
+func TestBuildYaraRuleNotEnoughStrings(t *testing.T) {
+	rule := buildYaraRule("goinfo_empty", &yaraSource{
+		packages:  []string{"main"},
+		functions: []string{"main.main", "main.init"},
+	})
+	if rule != "" {
+		t.Errorf("expected no rule to be generated, got rule:\n%s", rule)
+	}
+}
+
+func TestEscapeYaraString(t *testing.T) {
+	escaped := escapeYaraString("D:/渗透/\"go\"\\x")
+	expected := `D:/\xe6\xb8\x97\xe9\x80\x8f/\"go\"\\x`
+	if escaped != expected {
+		t.Errorf("expected %s got %s", expected, escaped)
+	}
+}
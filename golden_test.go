@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Rewrite the golden files with the actual results instead of comparing against them, e.g. 'go test -run TestGoPe -update'.
+var updateGolden = flag.Bool("update", false, "rewrite the golden files with the actual results")
+
+// Directory of the expected result of each sample, named after its sha256.
+const goldenDir = "testdata/golden"
+
+// Lines of unchanged context shown around each difference.
+const goldenDiffContext = 3
+
+// Drop empty strings, zero numbers, false, null and empty lists and objects, so the fields
+// a result doesn't set don't matter. Returns false when the whole value is empty.
+func pruneEmpty(value any) (any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			pruned, ok := pruneEmpty(field)
+			if !ok {
+				delete(v, key)
+				continue
+			}
+			v[key] = pruned
+		}
+		return v, len(v) > 0
+	case []any:
+		// Empty list items are kept so the position of the others doesn't change.
+		for i, item := range v {
+			v[i], _ = pruneEmpty(item)
+		}
+		return v, len(v) > 0
+	case string:
+		return v, v != ""
+	case json.Number:
+		return v, v.String() != "0"
+	case bool:
+		return v, v
+	}
+	return value, value != nil
+}
+
+// Encode a result as indented JSON with sorted keys and empty fields left out, so the same result always
+// gives the same bytes whether it came from a test run or a golden file.
+func canonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep large offsets exact rather than converting them to floats.
+	decoder.UseNumber()
+	var decoded any
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return nil, err
+	}
+	pruned, _ := pruneEmpty(decoded)
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(pruned)
+	if err != nil {
+		return nil, err
+	}
+	return canonical.Bytes(), nil
+}
+
+// Line by line difference between the expected and actual text, with '-' for lines only in the expected
+// text and '+' for lines only in the actual text. Differences are grouped into hunks with a few lines of context.
+func diffLines(expected string, actual string) string {
+	a := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	// Length of the longest common subsequence of a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		prefix string
+		text   string
+		line   int
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{" ", a[i], i + 1})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, diffLine{"-", a[i], i + 1})
+			i++
+		default:
+			lines = append(lines, diffLine{"+", b[j], i + 1})
+			j++
+		}
+	}
+
+	var diff strings.Builder
+	// Every hunk, including the first, starts with the line it begins at in the expected text.
+	lastShown := -2
+	for n := range lines {
+		changedNearby := false
+		for k := max(0, n-goldenDiffContext); k <= min(len(lines)-1, n+goldenDiffContext); k++ {
+			if lines[k].prefix != " " {
+				changedNearby = true
+				break
+			}
+		}
+		if !changedNearby {
+			continue
+		}
+		if n != lastShown+1 {
+			fmt.Fprintf(&diff, "@@ expected line %d\n", lines[n].line)
+		}
+		fmt.Fprintf(&diff, "%s %s\n", lines[n].prefix, lines[n].text)
+		lastShown = n
+	}
+	return diff.String()
+}
+
+// Compare a result with its golden file, or rewrite the golden file when the tests are run with -update.
+func assertGolden(t *testing.T, name string, result any) {
+	t.Helper()
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("failed to encode the result: %v", err)
+	}
+	actual, err := canonicalJSON(resultJSON)
+	if err != nil {
+		t.Fatalf("failed to encode the result: %v", err)
+	}
+	goldenPath := filepath.Join(goldenDir, name+".json")
+	if *updateGolden {
+		err = os.MkdirAll(goldenDir, 0o755)
+		if err == nil {
+			err = os.WriteFile(goldenPath, actual, 0o644)
+		}
+		if err != nil {
+			t.Fatalf("failed to update %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	goldenData, err := os.ReadFile(goldenPath)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("%s doesn't exist, run the test with -update to create it", goldenPath)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hand edited golden files don't have to be in the canonical layout.
+	expected, err := canonicalJSON(goldenData)
+	if err != nil {
+		t.Fatalf("%s isn't valid JSON: %v", goldenPath, err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("result doesn't match %s, run the test with -update to accept it\n%s", goldenPath, diffLines(string(expected), string(actual)))
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	expected := "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}\n"
+	actual := "{\n  \"a\": 1,\n  \"b\": 4,\n  \"c\": 3,\n  \"d\": 5\n}\n"
+	diff := diffLines(expected, actual)
+	for _, line := range []string{"-   \"b\": 2,", "+   \"b\": 4,", "-   \"c\": 3", "+   \"c\": 3,", "+   \"d\": 5", "@@ expected line 1"} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("expected %q in the diff\n%s", line, diff)
+		}
+	}
+	if diffLines(expected, expected) != "" {
+		t.Error("expected no diff for equal text")
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	canonical, err := canonicalJSON([]byte(`{"Status": "completed", "Message": "", "Events": [{"Features": {"go_file": [{"Value": "a<b", "Label": "", "Offset": 18446744073709551615, "Size": 0}]}}], "Children": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\n  \"Events\": [\n    {\n      \"Features\": {\n        \"go_file\": [\n          {\n            \"Offset\": 18446744073709551615,\n            \"Value\": \"a<b\"\n          }\n        ]\n      }\n    }\n  ],\n  \"Status\": \"completed\"\n}\n"
+	if string(canonical) != expected {
+		t.Errorf("unexpected canonical JSON\n%s", diffLines(expected, string(canonical)))
+	}
+}
+
+// Golden files are kept in the layout -update writes so regenerating them only shows real changes.
+func TestGoldenFilesCanonical(t *testing.T) {
+	goldenPaths, err := filepath.Glob(filepath.Join(goldenDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, goldenPath := range goldenPaths {
+		data, err := os.ReadFile(goldenPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		canonical, err := canonicalJSON(data)
+		if err != nil {
+			t.Errorf("%s isn't valid JSON: %v", goldenPath, err)
+			continue
+		}
+		if !bytes.Equal(data, canonical) {
+			t.Errorf("%s isn't in the canonical layout\n%s", goldenPath, diffLines(string(data), string(canonical)))
+		}
+	}
+}
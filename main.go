@@ -2,20 +2,19 @@ package main
 
 import (
 	"context"
-	debugBuildInfo "debug/buildinfo"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"log"
-	"strings"
+	"os"
 
 	"github.com/AustralianCyberSecurityCentre/azul-bedrock/v12/gosrc/events"
 	"github.com/AustralianCyberSecurityCentre/azul-bedrock/v12/gosrc/plugin"
-	"github.com/goretk/gore"
+	"github.com/AustralianCyberSecurityCentre/azul-plugin-goinfo.git/goinfo"
 )
 
-type GoInfoPlugin struct {
-	// The last panic error from a recovered panic.
-	panicError string
-}
+// Adapts the goinfo analysis to Azul, adding the report of each job's file to the job.
+// The plugin holds no per job state so one instance can run many jobs in parallel.
+type GoInfoPlugin struct{}
 
 func (gi *GoInfoPlugin) GetName() string {
 	return "GoInfo"
@@ -38,11 +37,37 @@ func (gi *GoInfoPlugin) GetFeatures() []events.PluginEntityFeature {
 		{Name: "go_package", Type: events.FeatureString, Description: "User defined packages in a Go binary"},
 		{Name: "go_package_function", Type: events.FeatureString, Description: "Functions in user defined packages"},
 		{Name: "go_package_method", Type: events.FeatureString, Description: "Methods in user defined packages"},
+		{Name: "go_method_receiver", Type: events.FeatureString, Description: "Receiver type of a method in a user defined package, labelled pointer or value, at the offset of the method"},
+		{Name: "go_generic_function", Type: events.FeatureString, Description: "Generic function or method of a user package, labelled with the type arguments of its instantiations"},
+		{Name: "go_generic_instantiation_count", Type: events.FeatureInteger, Description: "Number of instantiations of the generic function in the label"},
+		{Name: "go_closure", Type: events.FeatureString, Description: "Closure, goroutine body or deferred call of a user package, labelled with the function it is declared in"},
+		{Name: "go_closure_count", Type: events.FeatureInteger, Description: "Total number of closures, labelled when go_closure was capped"},
+		{Name: "go_function_closure_count", Type: events.FeatureInteger, Description: "Number of closures nested in the top level function in the label"},
+		{Name: "go_method_receiver_count", Type: events.FeatureInteger, Description: "Total number of method receivers, labelled when go_method_receiver was capped"},
 		{Name: "go_vendor_package", Type: events.FeatureString, Description: "Packages from 3rd party vendors in a Go binary"},
 		{Name: "go_file", Type: events.FeatureString, Description: "Files in a Go build"},
 		{Name: "go_type", Type: events.FeatureString, Description: "Types in a Go binary"},
 		{Name: "go_type_method", Type: events.FeatureString, Description: "Methods of a type"},
-		{Name: "malformed", Type: events.FeatureString, Description: "File appears to be a corrupted PE file."},
+		{Name: "go_buildmode", Type: events.FeatureString, Description: "Go build mode (exe, pie, c-shared, c-archive, plugin)"},
+		{Name: "go_linkmode", Type: events.FeatureString, Description: "Go link mode (internal, external)"},
+		{Name: "go_cgo_export", Type: events.FeatureString, Description: "Symbols exported with cgo from a c-shared Go library"},
+		{Name: "go_cgo_enabled", Type: events.FeatureString, Description: "Whether the Go binary links C code with cgo, labelled with the evidence used"},
+		{Name: "go_cgo_function", Type: events.FeatureString, Description: "C functions called from a cgo enabled Go binary"},
+		{Name: "go_cgo_library", Type: events.FeatureString, Description: "C libraries imported by a cgo enabled Go binary"},
+		{Name: "go_cgo_file", Type: events.FeatureString, Description: "Source files only present in a cgo build, these reveal the C source file names"},
+		{Name: "go_macho_slice", Type: events.FeatureString, Description: "Architecture of a Go slice in a universal Mach-O, labelled with its Go version"},
+		{Name: "go_macho_slice_mismatch", Type: events.FeatureString, Description: "Build detail that differs between the Go slices of a universal Mach-O"},
+		{Name: "go_analysis_fallback", Type: events.FeatureString, Description: "Reason only architecture independent Go metadata was extracted"},
+		{Name: "go_upx_packed", Type: events.FeatureString, Description: "Format of a UPX packed Go binary, labelled with the compression method or \"unsupported method\" when it couldn't be unpacked"},
+		{Name: "go_upx_warning", Type: events.FeatureString, Description: "Problem found while unpacking a UPX packed binary"},
+		{Name: "go_memory_base_address", Type: events.FeatureString, Description: "Base address of a memory dump inferred from the Go runtime moduledata"},
+		{Name: "go_package_function_count", Type: events.FeatureInteger, Description: "Total number of user package functions, labelled when go_package_function was capped"},
+		{Name: "go_package_method_count", Type: events.FeatureInteger, Description: "Total number of user package methods, labelled when go_package_method was capped"},
+		{Name: "go_type_count", Type: events.FeatureInteger, Description: "Total number of user types, labelled when go_type was capped"},
+		{Name: "go_type_method_count", Type: events.FeatureInteger, Description: "Total number of user type methods, labelled when go_type_method was capped"},
+		{Name: "go_analysis_truncated", Type: events.FeatureString, Description: "Extraction stage stopped early by its time budget or job cancellation, labelled with the reason. Results found before it stopped are still reported"},
+		{Name: "go_analysis_error", Type: events.FeatureString, Description: "Cause of an extraction stage failing, labelled with the stage. Results from the other stages are still reported"},
+		{Name: "malformed", Type: events.FeatureString, Description: "Structural anomaly in the file, labelled with its category. The Go metadata is still extracted where possible."},
 	}
 }
 
@@ -57,219 +82,101 @@ func (gi *GoInfoPlugin) GetDefaultSettings() *plugin.PluginSettings {
 		"executable/linux/elf64",
 		"executable/linux/elf32",
 		"executable/mach-o",
-	})
+	}).WithCustomSettings(goinfo.SettingDefaults())
 	return defaultSettings
 }
 
-func handleGorePanic(gi *GoInfoPlugin) {
-	goreRecover := recover()
-
-	if goreRecover != nil {
-		switch gorePanicMessage := goreRecover.(type) {
-		case string:
-			log.Printf("STRING PANIC %#v", gorePanicMessage)
-			gi.panicError = gorePanicMessage
-		default:
-			log.Printf("PyGore paniced and the message couldn't be retrieved the recover value is %#v", gorePanicMessage)
-			gi.panicError = "PyGore panic but the error couldn't be recovered."
-		}
-	} else {
-		// If there is no panic clear the message.
-		gi.panicError = ""
-	}
-}
-
-func (gi *GoInfoPlugin) openGoreFile(sourcePath string) (*gore.GoFile, error) {
-	defer handleGorePanic(gi)
-	return gore.Open(sourcePath)
-}
-
-func (gi *GoInfoPlugin) Execute(context context.Context, job *plugin.Job, inputUtils *plugin.PluginInputUtils) *plugin.PluginError {
+func (gi *GoInfoPlugin) Execute(ctx context.Context, job *plugin.Job, inputUtils *plugin.PluginInputUtils) *plugin.PluginError {
 	contentFilePath, pluginErr := job.GetContentPath()
 	if pluginErr != nil {
 		return pluginErr
 	}
-
-	goFile, err := gi.openGoreFile(contentFilePath)
-	// Opt out if the error is that the file is unsupported by pygore
-	if err != nil && strings.Contains(strings.ToLower(err.Error()), "unsupported") {
-		return plugin.NewPluginOptOut(fmt.Sprintf("File could not be opened by pygore with message %s", err.Error()))
-	}
-	if err != nil && strings.Contains(strings.ToLower(err.Error()), "fail to read string table length") {
-		pluginErr := job.AddFeature("malformed", fmt.Sprintf("PE file was corrupted and it's header couldn't be read with error %s", err.Error()))
-		if pluginErr != nil {
-			return pluginErr
-		}
-		return nil
+	// Settings come from the plugin config of each job, so deployments can choose the depth of each analysis.
+	settings, err := goinfo.ParseSettings(job.GetCustomSettings())
+	if err != nil {
+		return plugin.NewPluginError(plugin.ErrorException, "Invalid settings", "The goinfo plugin settings are invalid").WithCausalError(err)
 	}
+	report, err := goinfo.NewAnalyzer(settings).Analyze(ctx, contentFilePath)
 	if err != nil {
-		return plugin.NewPluginError(plugin.ErrorException, "Could not be opened", "Pygore could not open the file").WithCausalError(err)
+		return analysisPluginError(err)
 	}
-	// goFile is only nil if openGoreFile paniced, so handle the panic message here.
-	if goFile == nil {
-		if gi.panicError == "Unsupported architecture" {
-			return plugin.NewPluginOptOut(fmt.Sprintf("Gore paniced while trying to open the file with the panic message: '%s'", gi.panicError))
-		}
-		return plugin.NewPluginError(plugin.ErrorException, "Pygore Panic", fmt.Sprintf("Pygore paniced with a panic message: '%s'", gi.panicError))
+	// An opted out entity gets none of the features found before the analysis gave up.
+	if report.OptOut != "" {
+		return plugin.NewPluginOptOut(report.OptOut)
 	}
-	defer goFile.Close()
+	return addReportToJob(job, report)
+}
 
-	compilerVersion, err := goFile.GetCompilerVersion()
-	if compilerVersion == nil || (err != nil && strings.ToLower(err.Error()) == "no goversion found") {
-		return plugin.NewPluginOptOut("Not a go binary, no go version found.")
-	}
+// Labels of the streams added to the entity. The dispatcher only accepts the labels bedrock defines.
+const (
+	// A candidate YARA rule built from the Go metadata, a stream of text starting with "rule".
+	yaraRuleStreamLabel = events.DataLabelText
+	// The JSON analysis report of a Go binary, see goinfo.BinaryReport. It is told apart from the YARA rules by
+	// being a JSON object.
+	binaryReportStreamLabel = events.DataLabelText
+)
 
-	buildInfo, err := debugBuildInfo.ReadFile(contentFilePath)
-	// Extract build info if the file is a valid go binary.
-	if err == nil {
-		for _, s := range buildInfo.Settings {
-			pluginErr = job.AddFeatureWithExtra("go_compiler_flag", s.Value, &plugin.AddFeatureOptions{
-				Label: s.Key,
+// Add the features, unpacked child, YARA rules and binary reports of an analysis to the job.
+func addReportToJob(job *plugin.Job, report *goinfo.Report) *plugin.PluginError {
+	var pluginErr *plugin.PluginError
+	for _, feature := range report.Features {
+		if feature.Label == "" && feature.Offset == 0 && feature.Size == 0 {
+			pluginErr = job.AddFeature(feature.Name, feature.Value)
+		} else {
+			pluginErr = job.AddFeatureWithExtra(feature.Name, feature.Value, &plugin.AddFeatureOptions{
+				Label:  feature.Label,
+				Offset: feature.Offset,
+				Size:   feature.Size,
 			})
-			if pluginErr != nil {
-				return pluginErr
-			}
-		}
-	}
-
-	// Get core compiler information.
-	pluginErr = job.AddFeature("go_build_id", goFile.BuildID)
-	if pluginErr != nil {
-		return pluginErr
-	}
-	if goFile.BuildInfo != nil && goFile.BuildInfo.Compiler != nil {
-		pluginErr = job.AddFeature("go_compiler_version", goFile.BuildInfo.Compiler.Name)
-		if pluginErr != nil {
-			return pluginErr
 		}
-		pluginErr = job.AddFeature("go_compiler_timestamp", goFile.BuildInfo.Compiler.Timestamp)
 		if pluginErr != nil {
 			return pluginErr
 		}
 	}
-
-	packageList, err := goFile.GetPackages()
-	if err != nil {
-		return plugin.NewPluginError(
-			plugin.ErrorException,
-			"Failed to get packages",
-			"Failed to get the packages for the go binary.",
-		).WithCausalError(err)
-	}
-	goPackageSet := map[string]interface{}{}
-	// Get all the functions and methods in this package.
-	for _, pkg := range packageList {
-		pluginErr = job.AddFeature("go_package", pkg.Name)
-		goPackageSet[pkg.Name] = nil
+	if report.Unpacked != nil {
+		pluginErr = job.AddChildBytes(report.Unpacked, map[string]string{"action": "upx_unpacked"})
 		if pluginErr != nil {
 			return pluginErr
 		}
-		if pkg.Filepath != "." {
-			pluginErr = job.AddFeature("go_file", pkg.Filepath)
-			if pluginErr != nil {
-				return pluginErr
-			}
-		}
-		// Add package functions
-		for _, pkgFunc := range pkg.Functions {
-			pluginErr = job.AddFeatureWithExtra(
-				"go_package_function",
-				pkgFunc.Name,
-				&plugin.AddFeatureOptions{
-					Label:  pkgFunc.PackageName,
-					Offset: pkgFunc.Offset,
-					Size:   pkgFunc.End - pkgFunc.Offset,
-				},
-			)
-			if pluginErr != nil {
-				return pluginErr
-			}
-		}
-		// Add package methods
-		for _, pkgMethods := range pkg.Methods {
-			pluginErr = job.AddFeatureWithExtra(
-				"go_package_method",
-				pkgMethods.Name,
-				&plugin.AddFeatureOptions{
-					Label:  pkgMethods.PackageName,
-					Offset: pkgMethods.Offset,
-					Size:   pkgMethods.End - pkgMethods.Offset,
-				},
-			)
-			if pluginErr != nil {
-				return pluginErr
-			}
-		}
-	}
-	// Get all the vendor package names.
-	vendorPackages, err := goFile.GetVendors()
-	if err != nil {
-		return plugin.NewPluginError(
-			plugin.ErrorException,
-			"Failed to get vendor packages",
-			"Failed to get the vendor packages for the go binary.",
-		).WithCausalError(err)
 	}
-	for _, vendorPackage := range vendorPackages {
-		pluginErr = job.AddFeature("go_vendor_package", vendorPackage.Name)
+	for _, yaraRule := range report.YaraRules {
+		pluginErr = job.AddAugmentedStream(yaraRuleStreamLabel, []byte(yaraRule))
 		if pluginErr != nil {
 			return pluginErr
 		}
 	}
-
-	// Add User definied GoTypes.
-	goTypes, err := goFile.GetTypes()
-	if err != nil {
-		return plugin.NewPluginError(
-			plugin.ErrorException,
-			"Failed to get GoTypes",
-			"Failed to get the GoTypes for the go binary.",
-		).WithCausalError(err)
-	}
-	for _, goType := range goTypes {
-		/*
-			This will get all types defined in the binary including ones from standard libraries
-			Somehow the get_packages() function only gets user defined packages, so we check the
-			type's packagePath matches one of these to only get the user defined types as well
-		*/
-		_, ok := goPackageSet[goType.PackagePath]
-		if !ok {
-			continue
+	for _, binary := range report.Binaries {
+		binaryJSON, err := json.Marshal(binary)
+		if err != nil {
+			return plugin.NewPluginError(plugin.ErrorException, "Report failed", "Failed to encode the JSON analysis report").WithCausalError(err)
 		}
-
-		pluginErr = job.AddFeatureWithExtra(
-			"go_type",
-			goType.Name,
-			&plugin.AddFeatureOptions{
-				Label:  goType.Kind.String(),
-				Offset: goType.Addr,
-				Size:   uint64(goType.Length),
-			},
-		)
+		pluginErr = job.AddAugmentedStream(binaryReportStreamLabel, binaryJSON)
 		if pluginErr != nil {
 			return pluginErr
 		}
-		/*
-			Type methods also have an offset value however it is an offset relative to
-			a variable location in the binary, so excluding it in the below feature.
-			Some of the methods below may be duplicated by the go_package_method
-			feature anyway, which does extract the file offset
-		*/
-		for _, goTypeMethod := range goType.Methods {
-			pluginErr = job.AddFeatureWithExtra(
-				"go_type_method",
-				goTypeMethod.Name,
-				&plugin.AddFeatureOptions{Label: goType.Name},
-			)
-			if pluginErr != nil {
-				return pluginErr
-			}
-		}
 	}
 	return nil
 }
 
+// Convert an error that stopped the analysis into the plugin error reported for the job.
+func analysisPluginError(err error) *plugin.PluginError {
+	var analysisErr *goinfo.Error
+	if errors.As(err, &analysisErr) {
+		return plugin.NewPluginError(plugin.ErrorException, analysisErr.Title, analysisErr.Message).WithCausalError(analysisErr.Err)
+	}
+	return plugin.NewPluginError(plugin.ErrorException, "Analysis failed", "Failed to analyse the Go binary").WithCausalError(err)
+}
+
 func main() {
+	// Analyse local files without the dispatcher, e.g. 'azul-goinfo analyze sample.exe'.
+	// Without the plugin runtime the settings are read from the environment.
+	if len(os.Args) > 1 && os.Args[1] == analyzeCommand {
+		settings, err := goinfo.LoadSettings(os.Environ())
+		if err != nil {
+			log.Fatalf("invalid plugin settings: %v", err)
+		}
+		os.Exit(runAnalyzeCommand(settings, os.Args[2:], os.Stdout, os.Stderr))
+	}
 	pr := plugin.NewPluginRunner(&GoInfoPlugin{})
 	pr.Run()
 }